@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/form3tech-oss/interview-accountapi/form3/form3test"
+)
+
+// newAdminHandler serves the admin endpoints, see the package documentation.
+func newAdminHandler(fake *form3test.Fake, scenario *scenarioHandler, seed form3test.Snapshot) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/admin/reset", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		fake.Restore(seed)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("/admin/snapshot", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			form3test.WriteSnapshot(w, fake.Snapshot())
+		case http.MethodPut:
+			snapshot, err := form3test.ReadSnapshot(r.Body)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			fake.Restore(snapshot)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	})
+
+	mux.HandleFunc("/admin/scenario", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, scenario.Scenario())
+		case http.MethodPut:
+			var s Scenario
+			if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			scenario.SetScenario(s)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	})
+
+	return mux
+}
@@ -0,0 +1,123 @@
+// Command form3-fake serves an in-memory fake of the Form3 API, as a local stand-in for the real one.
+// It serves accounts, and bank IDs, BICs, claims, limits, organisations, roles, subscriptions and users, see form3test.Fake.
+//
+// State can be seeded from a JSON snapshot file, and optionally saved back to it on shutdown:
+//
+//	form3-fake -addr :8080 -snapshot accounts.json -save
+//
+// Failures can be scripted with flags or at runtime through the admin endpoints:
+//
+//	form3-fake -latency 200ms -error-rate 0.1 -throttle-every 20 -throttle-burst 5
+//
+// Admin endpoints (never subject to the scenario):
+//
+//	POST /admin/reset       restore the state loaded at startup
+//	GET  /admin/snapshot    current state as a snapshot
+//	PUT  /admin/snapshot    replace the state with a snapshot
+//	GET  /admin/scenario    current failure scenario
+//	PUT  /admin/scenario    replace the failure scenario, e.g. {"latency_ms": 200, "error_rate": 0.1}
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/form3tech-oss/interview-accountapi/form3/form3test"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	snapshotPath := flag.String("snapshot", "", "JSON snapshot file to load the initial state from")
+	save := flag.Bool("save", false, "save the state to the snapshot file on shutdown")
+	latency := flag.Duration("latency", 0, "delay added to every request")
+	errorRate := flag.Float64("error-rate", 0, "fraction of requests, 0 to 1, failing with 500 Internal Server Error")
+	throttleEvery := flag.Int("throttle-every", 0, "requests let through before each burst of 429 Too Many Requests")
+	throttleBurst := flag.Int("throttle-burst", 0, "requests throttled in each burst")
+	randSeed := flag.Int64("rand-seed", 1, "seed for the failure scenario, so runs are reproducible")
+	flag.Parse()
+
+	seed, err := loadSnapshot(*snapshotPath)
+	if err != nil {
+		log.Fatalf("loading snapshot: %s", err)
+	}
+
+	fake := form3test.NewFake()
+	fake.Restore(seed)
+
+	scenario := newScenarioHandler(fake, Scenario{
+		LatencyMS:     int(*latency / time.Millisecond),
+		ErrorRate:     *errorRate,
+		ThrottleEvery: *throttleEvery,
+		ThrottleBurst: *throttleBurst,
+	}, *randSeed)
+
+	mux := http.NewServeMux()
+	mux.Handle("/admin/", newAdminHandler(fake, scenario, seed))
+	mux.Handle("/", scenario)
+
+	srv := &http.Server{Addr: *addr, Handler: mux}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+
+	resources := 0
+	for _, r := range seed.Resources {
+		resources += len(r)
+	}
+	log.Printf("serving the fake Form3 API on %s with %d accounts and %d other resources", *addr, len(seed.Accounts), resources)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+
+	if *save && *snapshotPath != "" {
+		if err := saveSnapshot(*snapshotPath, fake.Snapshot()); err != nil {
+			log.Fatalf("saving snapshot: %s", err)
+		}
+		log.Printf("saved the state to %s", *snapshotPath)
+	}
+}
+
+// loadSnapshot reads the snapshot at path. A missing file, or no path, is an empty state.
+func loadSnapshot(path string) (form3test.Snapshot, error) {
+	if path == "" {
+		return form3test.Snapshot{}, nil
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return form3test.Snapshot{}, nil
+	}
+	if err != nil {
+		return form3test.Snapshot{}, err
+	}
+	defer f.Close()
+
+	return form3test.ReadSnapshot(f)
+}
+
+func saveSnapshot(path string, snapshot form3test.Snapshot) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := form3test.WriteSnapshot(f, snapshot); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Scenario describes the failures injected into requests to the fake API.
+type Scenario struct {
+	LatencyMS     int     `json:"latency_ms"`     // delay added to every request
+	ErrorRate     float64 `json:"error_rate"`     // fraction of requests failing with 500
+	ThrottleEvery int     `json:"throttle_every"` // requests let through before each burst of 429s
+	ThrottleBurst int     `json:"throttle_burst"` // requests throttled in each burst
+}
+
+// scenarioHandler injects the failures of a Scenario before passing requests on to next.
+type scenarioHandler struct {
+	next http.Handler
+
+	mu       sync.Mutex
+	scenario Scenario
+	rand     *rand.Rand
+	requests int // requests seen since the scenario was set, for throttling bursts
+}
+
+func newScenarioHandler(next http.Handler, scenario Scenario, seed int64) *scenarioHandler {
+	return &scenarioHandler{
+		next:     next,
+		scenario: scenario,
+		rand:     rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Scenario returns the current scenario.
+func (h *scenarioHandler) Scenario() Scenario {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.scenario
+}
+
+// SetScenario replaces the scenario, starting throttling afresh.
+func (h *scenarioHandler) SetScenario(scenario Scenario) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.scenario = scenario
+	h.requests = 0
+}
+
+func (h *scenarioHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	scenario := h.scenario
+	n := h.requests
+	h.requests++
+	fail := scenario.ErrorRate > 0 && h.rand.Float64() < scenario.ErrorRate
+	h.mu.Unlock()
+
+	if scenario.LatencyMS > 0 {
+		select {
+		case <-time.After(time.Duration(scenario.LatencyMS) * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if scenario.ThrottleEvery > 0 && scenario.ThrottleBurst > 0 && n%(scenario.ThrottleEvery+scenario.ThrottleBurst) >= scenario.ThrottleEvery {
+		w.Header().Set("Retry-After", "1")
+		writeError(w, http.StatusTooManyRequests, "too many requests")
+		return
+	}
+
+	if fail {
+		writeError(w, http.StatusInternalServerError, "injected failure")
+		return
+	}
+
+	h.next.ServeHTTP(w, r)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error_message": message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
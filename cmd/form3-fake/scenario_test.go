@@ -0,0 +1,78 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/form3tech-oss/interview-accountapi/form3/form3test"
+)
+
+func Test_ScenarioHandler_Throttle_Success(t *testing.T) {
+	h := newScenarioHandler(http.NotFoundHandler(), Scenario{ThrottleEvery: 2, ThrottleBurst: 1}, 1)
+
+	var statuses []int
+	for i := 0; i < 6; i++ {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/v1/organisation/accounts", nil))
+		statuses = append(statuses, rec.Code)
+	}
+
+	expected := []int{404, 404, 429, 404, 404, 429}
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Error("Expected:", expected, "Got:", statuses)
+	}
+}
+
+func Test_ScenarioHandler_ErrorRate_Deterministic(t *testing.T) {
+	run := func() []int {
+		h := newScenarioHandler(http.NotFoundHandler(), Scenario{ErrorRate: 0.5}, 42)
+
+		var statuses []int
+		for i := 0; i < 20; i++ {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest("GET", "/v1/organisation/accounts", nil))
+			statuses = append(statuses, rec.Code)
+		}
+		return statuses
+	}
+
+	first, second := run(), run()
+	if !reflect.DeepEqual(first, second) {
+		t.Error("Expected the same failures for the same seed, Got:", first, second)
+	}
+	if !containsStatus(first, http.StatusInternalServerError) || !containsStatus(first, http.StatusNotFound) {
+		t.Error("Expected: a mix of failures and successes", "Got:", first)
+	}
+}
+
+func Test_AdminHandler_Reset_Success(t *testing.T) {
+	seed, err := form3test.ReadSnapshot(strings.NewReader(`{"accounts": [{"id": "158f775c-4ecd-4861-b33d-30df9a29de78", "type": "accounts", "attributes": {"country": "GB"}}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fake := form3test.NewFake()
+	h := newAdminHandler(fake, newScenarioHandler(fake, Scenario{}, 1), seed)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("POST", "/admin/reset", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Error("Expected:", http.StatusNoContent, "Got:", rec.Code)
+	}
+
+	if len(fake.Accounts()) != 1 {
+		t.Error("Expected: the seeded account", "Got:", fake.Accounts())
+	}
+}
+
+func containsStatus(statuses []int, status int) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
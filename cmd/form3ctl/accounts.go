@@ -0,0 +1,331 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+)
+
+// accountsCommand runs the accounts subcommands.
+type accountsCommand struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	getenv env
+}
+
+// flagSet returns the flags of a subcommand, with the common flags registered in common.
+func (c *accountsCommand) flagSet(name string, common *commonFlags) *flag.FlagSet {
+	fs := flag.NewFlagSet("form3ctl accounts "+name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	common.register(fs)
+	return fs
+}
+
+// accounts parses args and returns the positional arguments and the accounts service to use.
+func (c *accountsCommand) accounts(fs *flag.FlagSet, common *commonFlags, args []string, positional int) ([]string, form3.AccountsAPI, error) {
+	rest, err := parse(fs, args)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rest) != positional {
+		return nil, nil, usagef("%s takes %d argument(s), got %d", fs.Name(), positional, len(rest))
+	}
+	if err := checkFormat(common.output); err != nil {
+		return nil, nil, err
+	}
+
+	client, err := common.client(c.getenv, c.stderr)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rest, client.Accounts(), nil
+}
+
+// list -> lists accounts, one page or all of them.
+func (c *accountsCommand) list(args []string) error {
+	var common commonFlags
+	fs := c.flagSet("list", &common)
+	filters := &filterFlag{}
+	fs.Var(filters, "filter", "only list accounts with attribute=value[,value...], may be repeated")
+	page := fs.Int("page", 0, "page number, starting at 0")
+	size := fs.Int("size", 0, "accounts per page (default set by the API)")
+	all := fs.Bool("all", false, "list every page")
+
+	_, accounts, err := c.accounts(fs, &common, args, 0)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range filters.filters {
+		accounts = accounts.Filter(f.attribute, f.values...)
+	}
+	if *size > 0 {
+		accounts = accounts.Size(*size)
+	}
+
+	var list []form3.Account
+	var res *http.Response
+	var listErr error
+	if *all {
+		list, res, listErr = accounts.ListAll(context.Background())
+	} else {
+		list, res, listErr = accounts.Number(*page).List(context.Background())
+	}
+	if err := checkAPI(res, listErr); err != nil {
+		return err
+	}
+
+	return writeAccounts(c.stdout, common.output, list, false)
+}
+
+// get -> prints a single account.
+func (c *accountsCommand) get(args []string) error {
+	var common commonFlags
+	fs := c.flagSet("get", &common)
+
+	rest, accounts, err := c.accounts(fs, &common, args, 1)
+	if err != nil {
+		return err
+	}
+
+	account, res, err := accounts.Fetch(context.Background(), rest[0])
+	if err := checkAPI(res, err); err != nil {
+		return err
+	}
+
+	return writeAccounts(c.stdout, common.output, []form3.Account{*account}, true)
+}
+
+// create -> creates an account from a JSON file, or stdin.
+func (c *accountsCommand) create(args []string) error {
+	var common commonFlags
+	fs := c.flagSet("create", &common)
+	file := fs.String("f", "-", "JSON file with the account, - for stdin")
+
+	_, accounts, err := c.accounts(fs, &common, args, 0)
+	if err != nil {
+		return err
+	}
+
+	raw, err := c.readAccount(*file)
+	if err != nil {
+		return err
+	}
+
+	var account form3.Account
+	if err := json.Unmarshal(raw, &account); err != nil {
+		return fmt.Errorf("%s: %w", *file, err)
+	}
+	if account.Type == "" {
+		account.Type = "accounts"
+	}
+
+	created, res, err := accounts.Create(context.Background(), &account)
+	if err := checkAPI(res, err); err != nil {
+		return err
+	}
+
+	return writeAccounts(c.stdout, common.output, []form3.Account{*created}, true)
+}
+
+// update -> changes the current version of an account with a JSON file and/or --set attribute=value.
+// Attributes not given keep their current values.
+func (c *accountsCommand) update(args []string) error {
+	var common commonFlags
+	fs := c.flagSet("update", &common)
+	file := fs.String("f", "", "JSON file with the members to change, - for stdin")
+	sets := &setFlag{}
+	fs.Var(sets, "set", "set attribute=value, may be repeated")
+
+	rest, accounts, err := c.accounts(fs, &common, args, 1)
+	if err != nil {
+		return err
+	}
+	if *file == "" && len(sets.sets) == 0 {
+		return usagef("update needs -f or --set")
+	}
+
+	current, res, err := accounts.Fetch(context.Background(), rest[0])
+	if err := checkAPI(res, err); err != nil {
+		return err
+	}
+
+	account, err := toMap(current)
+	if err != nil {
+		return err
+	}
+	attributes, _ := account["attributes"].(map[string]interface{})
+	if attributes == nil {
+		attributes = map[string]interface{}{}
+	}
+
+	if *file != "" {
+		raw, err := c.readAccount(*file)
+		if err != nil {
+			return err
+		}
+		var changes map[string]interface{}
+		if err := json.Unmarshal(raw, &changes); err != nil {
+			return fmt.Errorf("%s: %w", *file, err)
+		}
+		for key, value := range changes {
+			if changed, ok := value.(map[string]interface{}); ok && key == "attributes" {
+				for attr, v := range changed {
+					attributes[attr] = v
+				}
+				continue
+			}
+			account[key] = value
+		}
+	}
+	for _, s := range sets.sets {
+		attributes[s.attribute] = s.value
+	}
+	account["attributes"] = attributes
+
+	// The account being updated is the one named on the command line, at the version just fetched
+	// unless the file says otherwise.
+	account["id"] = rest[0]
+
+	b, _ := json.Marshal(account)
+	var updated form3.Account
+	if err := json.Unmarshal(b, &updated); err != nil {
+		return err
+	}
+
+	result, res, err := accounts.Update(context.Background(), &updated)
+	if err := checkAPI(res, err); err != nil {
+		return err
+	}
+
+	return writeAccounts(c.stdout, common.output, []form3.Account{*result}, true)
+}
+
+// delete -> deletes an account, at its current version unless --version is given.
+func (c *accountsCommand) delete(args []string) error {
+	var common commonFlags
+	fs := c.flagSet("delete", &common)
+	version := fs.Int("version", -1, "version to delete (default the current version)")
+
+	rest, accounts, err := c.accounts(fs, &common, args, 1)
+	if err != nil {
+		return err
+	}
+
+	if *version < 0 {
+		current, res, err := accounts.Fetch(context.Background(), rest[0])
+		if err := checkAPI(res, err); err != nil {
+			return err
+		}
+		*version = current.Version
+	}
+
+	_, res, err := accounts.Delete(context.Background(), rest[0], *version)
+	return checkAPI(res, err)
+}
+
+// readAccount reads the JSON account in file (stdin for -), unwrapping it from {"data": ...} if needed.
+func (c *accountsCommand) readAccount(file string) ([]byte, error) {
+	var raw []byte
+	var err error
+	if file == "-" {
+		raw, err = ioutil.ReadAll(c.stdin)
+	} else {
+		raw, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if json.Unmarshal(raw, &envelope) == nil && len(envelope.Data) > 0 {
+		return envelope.Data, nil
+	}
+	return raw, nil
+}
+
+// toMap converts an account to its JSON object.
+func toMap(account *form3.Account) (map[string]interface{}, error) {
+	b, err := json.Marshal(account)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	return m, json.Unmarshal(b, &m)
+}
+
+type filter struct {
+	attribute string
+	values    []string
+}
+
+// filterFlag is a repeatable --filter attribute=value[,value...] flag.
+type filterFlag struct {
+	filters []filter
+}
+
+func (f *filterFlag) String() string {
+	var s []string
+	for _, filter := range f.filters {
+		s = append(s, filter.attribute+"="+strings.Join(filter.values, ","))
+	}
+	return strings.Join(s, " ")
+}
+
+func (f *filterFlag) Set(value string) error {
+	attribute, values, ok := strings.Cut(value, "=")
+	if !ok || attribute == "" || values == "" {
+		return fmt.Errorf("want attribute=value[,value...], got %q", value)
+	}
+	f.filters = append(f.filters, filter{attribute: attribute, values: strings.Split(values, ",")})
+	return nil
+}
+
+type set struct {
+	attribute string
+	value     interface{}
+}
+
+// setFlag is a repeatable --set attribute=value flag. Values that are JSON booleans, arrays or objects are
+// set as such, e.g. --set joint_account=true or --set 'alternative_bank_account_names=["Sam"]', anything else
+// as a string, so that account numbers keep their leading zeros.
+type setFlag struct {
+	sets []set
+}
+
+func (f *setFlag) String() string {
+	var s []string
+	for _, set := range f.sets {
+		s = append(s, fmt.Sprintf("%s=%v", set.attribute, set.value))
+	}
+	return strings.Join(s, " ")
+}
+
+func (f *setFlag) Set(value string) error {
+	attribute, raw, ok := strings.Cut(value, "=")
+	if !ok || attribute == "" {
+		return fmt.Errorf("want attribute=value, got %q", value)
+	}
+
+	var v interface{} = raw
+	var parsed interface{}
+	if json.Unmarshal([]byte(raw), &parsed) == nil {
+		switch parsed.(type) {
+		case bool, []interface{}, map[string]interface{}:
+			v = parsed
+		}
+	}
+	f.sets = append(f.sets, set{attribute: attribute, value: v})
+	return nil
+}
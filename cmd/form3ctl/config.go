@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+	"github.com/form3tech-oss/interview-accountapi/form3/form3config"
+)
+
+// commonFlags are the flags taken by every command.
+type commonFlags struct {
+	config   string
+	profile  string
+	settings form3.Profile
+	output   string
+	verbose  bool
+}
+
+func (f *commonFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.config, "config", "", "config file (default $FORM3_CONFIG or ~/.form3/config.yaml)")
+	fs.StringVar(&f.profile, "profile", "", "profile of the config file to use (default $FORM3_PROFILE)")
+	fs.StringVar(&f.settings.BaseURL, "base-url", "", "API base URL, e.g. https://api.staging-form3.tech (default $FORM3_BASE_URL)")
+	fs.StringVar(&f.settings.Token, "token", "", "bearer token (default $FORM3_TOKEN)")
+	fs.StringVar(&f.settings.OrganisationID, "organisation-id", "", "organisation of created accounts (default $FORM3_ORGANISATION_ID)")
+	fs.StringVar(&f.output, "o", "table", "output format: table, json, yaml or csv")
+	fs.BoolVar(&f.verbose, "v", false, "log requests to stderr")
+}
+
+// client creates a client from the flags, then the environment and config file as form3config.NewClientFromEnv does.
+func (f *commonFlags) client(getenv env, stderr io.Writer) (*form3.Client, error) {
+	profile, err := form3config.ProfileFromEnv(func(key string) string {
+		switch {
+		case key == form3config.EnvConfig && f.config != "":
+			return f.config
+		case key == form3config.EnvProfile && f.profile != "":
+			return f.profile
+		}
+		return getenv(key)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if f.settings.BaseURL != "" {
+		profile.BaseURL = f.settings.BaseURL
+	}
+	if f.settings.Token != "" {
+		profile.Token = f.settings.Token
+	}
+	if f.settings.OrganisationID != "" {
+		profile.OrganisationID = f.settings.OrganisationID
+	}
+
+	options, err := profile.Options()
+	if err != nil {
+		return nil, err
+	}
+
+	options = append(options, form3.SetInfoLog(nil), form3.SetErrorLog(nil))
+	if f.verbose {
+		options = append(options,
+			form3.SetInfoLog(log.New(stderr, "[form3_info]", log.LstdFlags)),
+			form3.SetErrorLog(log.New(stderr, "[form3_error]", log.LstdFlags)),
+		)
+	}
+
+	return form3.NewClient(options...)
+}
+
+// parse parses args with fs, allowing flags after positional arguments, e.g. "get <id> -o json".
+// It returns the positional arguments.
+func parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, &usageError{err.Error()}
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+	"github.com/form3tech-oss/interview-accountapi/form3/form3test"
+	"github.com/form3tech-oss/interview-accountapi/form3/form3test/factory"
+)
+
+// testRun runs form3ctl against fake, configured through the fake profile of a config file.
+func testRun(t *testing.T, fake *form3test.Fake, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	config := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(config, []byte("profile: sandbox\nprofiles:\n  sandbox:\n    base_url: https://unused.example.com\n  fake:\n    base_url: "+srv.URL+"\n"), 0600)
+
+	environment := map[string]string{
+		"FORM3_CONFIG":  config,
+		"FORM3_PROFILE": "fake",
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr, func(key string) string { return environment[key] })
+	return code, stdout.String(), stderr.String()
+}
+
+func Test_List_Success(t *testing.T) {
+	f := factory.NewSeeded(1)
+	fake := form3test.NewFake()
+	fake.Seed(f.Account().Country("GB").BuildN(3)...)
+	fake.Seed(f.Account().Country("FR").Build())
+
+	code, stdout, stderr := testRun(t, fake, "", "accounts", "list", "--filter", "country=GB", "--all", "-o", "csv")
+	if code != exitOK {
+		t.Fatal("Expected:", exitOK, "Got:", code, stderr)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 4 {
+		t.Error("Expected:", 4, "Got:", len(lines), stdout)
+	}
+	if lines[0] != "ID,COUNTRY,BANK ID,BANK ID CODE,ACCOUNT NUMBER,IBAN,BIC,VERSION" {
+		t.Error("Expected: header", "Got:", lines[0])
+	}
+}
+
+func Test_Get_Success(t *testing.T) {
+	account := factory.NewSeeded(1).Account().Build()
+	fake := form3test.NewFake()
+	fake.Seed(account)
+
+	// Flags may come after the account ID
+	code, stdout, stderr := testRun(t, fake, "", "accounts", "get", account.ID, "-o", "json")
+	if code != exitOK {
+		t.Fatal("Expected:", exitOK, "Got:", code, stderr)
+	}
+
+	var got form3.Account
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != account.ID || got.Attributes.Iban != account.Attributes.Iban {
+		t.Error("Expected:", account, "Got:", got)
+	}
+}
+
+func Test_Get_NotFound_Failure(t *testing.T) {
+	code, _, stderr := testRun(t, form3test.NewFake(), "", "accounts", "get", "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+	if code != exitNotFound {
+		t.Error("Expected:", exitNotFound, "Got:", code)
+	}
+	if !strings.Contains(stderr, "404") {
+		t.Error("Expected: 404 in", stderr)
+	}
+}
+
+func Test_Create_Success(t *testing.T) {
+	account := factory.NewSeeded(1).Account().Build()
+	body, _ := json.Marshal(map[string]interface{}{"data": account})
+	fake := form3test.NewFake()
+
+	code, stdout, stderr := testRun(t, fake, string(body), "accounts", "create", "-o", "yaml")
+	if code != exitOK {
+		t.Fatal("Expected:", exitOK, "Got:", code, stderr)
+	}
+	if !strings.Contains(stdout, "id: "+account.ID) {
+		t.Error("Expected: id in", stdout)
+	}
+	if len(fake.Accounts()) != 1 {
+		t.Error("Expected:", 1, "Got:", len(fake.Accounts()))
+	}
+}
+
+func Test_Create_Invalid_Failure(t *testing.T) {
+	code, _, _ := testRun(t, form3test.NewFake(), `{"id": "not-a-uuid", "attributes": {"country": "GB"}}`, "accounts", "create")
+	if code != exitInvalid {
+		t.Error("Expected:", exitInvalid, "Got:", code)
+	}
+}
+
+func Test_Update_Success(t *testing.T) {
+	account := factory.NewSeeded(1).Account().Build()
+	fake := form3test.NewFake()
+	fake.Seed(account)
+
+	code, _, stderr := testRun(t, fake, "", "accounts", "update", account.ID, "--set", "bank_account_name=Sam Holder", "--set", "joint_account=true")
+	if code != exitOK {
+		t.Fatal("Expected:", exitOK, "Got:", code, stderr)
+	}
+
+	got := fake.Accounts()[0]
+	if got.Attributes.BankAccountName != "Sam Holder" || !got.Attributes.JointAccount {
+		t.Error("Expected: updated attributes", "Got:", got.Attributes)
+	}
+	if got.Attributes.Iban != account.Attributes.Iban {
+		t.Error("Expected:", account.Attributes.Iban, "Got:", got.Attributes.Iban)
+	}
+}
+
+func Test_Delete_Success(t *testing.T) {
+	account := factory.NewSeeded(1).Account().Version(2).Build()
+	fake := form3test.NewFake()
+	fake.Seed(account)
+
+	code, _, stderr := testRun(t, fake, "", "accounts", "delete", account.ID)
+	if code != exitOK {
+		t.Fatal("Expected:", exitOK, "Got:", code, stderr)
+	}
+	if len(fake.Accounts()) != 0 {
+		t.Error("Expected:", 0, "Got:", len(fake.Accounts()))
+	}
+}
+
+func Test_Delete_Conflict_Failure(t *testing.T) {
+	account := factory.NewSeeded(1).Account().Version(2).Build()
+	fake := form3test.NewFake()
+	fake.Seed(account)
+
+	code, _, _ := testRun(t, fake, "", "accounts", "delete", account.ID, "--version", "0")
+	if code != exitConflict {
+		t.Error("Expected:", exitConflict, "Got:", code)
+	}
+}
+
+func Test_Usage_Failure(t *testing.T) {
+	for _, args := range [][]string{
+		{},
+		{"payments", "list"},
+		{"accounts", "get"},
+		{"accounts", "list", "-o", "xml"},
+		{"accounts", "list", "--filter", "country"},
+	} {
+		code, _, _ := testRun(t, form3test.NewFake(), "", args...)
+		if code != exitUsage {
+			t.Error("Expected:", exitUsage, "Got:", code, args)
+		}
+	}
+}
+
+func Test_Network_Failure(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	getenv := func(key string) string {
+		return map[string]string{"FORM3_CONFIG": os.DevNull, "FORM3_BASE_URL": "http://127.0.0.1:1"}[key]
+	}
+
+	code := run([]string{"accounts", "list"}, strings.NewReader(""), &stdout, &stderr, getenv)
+	if code != exitNetwork {
+		t.Error("Expected:", exitNetwork, "Got:", code, stderr.String())
+	}
+}
+
+func Test_Profile_Failure(t *testing.T) {
+	code, _, stderr := testRun(t, form3test.NewFake(), "", "accounts", "list", "--profile", "production")
+	if code != exitError {
+		t.Error("Expected:", exitError, "Got:", code)
+	}
+	if !strings.Contains(stderr, `profile "production" not found`) {
+		t.Error("Expected: profile not found in", stderr)
+	}
+}
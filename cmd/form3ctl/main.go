@@ -0,0 +1,206 @@
+// Command form3ctl manages Form3 accounts from the command line.
+//
+// Usage:
+//
+//	form3ctl accounts list   [--filter attribute=value[,value...]]... [--page n] [--size n] [--all]
+//	form3ctl accounts get    <account_id>
+//	form3ctl accounts create [-f file.json]               (reads stdin without -f, or with -f -)
+//	form3ctl accounts update <account_id> [-f file.json] [--set attribute=value]...
+//	form3ctl accounts delete <account_id> [--version n]    (deletes the current version without --version)
+//
+// Every command takes -o table|json|yaml|csv and the connection flags --base-url, --token and --organisation-id.
+// Flags override the FORM3_* environment variables and the profiles of the config file, which are used as by
+// form3config.NewClientFromEnv. --config and --profile choose the config file and profile:
+//
+//	profile: sandbox
+//	profiles:
+//	  sandbox:
+//	    base_url: https://api.staging-form3.tech
+//	    organisation_id: eb0bd6f5-c3f5-44b2-b677-acd23cdde73c
+//	    signing_key_id: 75a8ba12-fff2-4a52-ad8a-e8b34c5ccec8
+//	    signing_key_path: sandbox.pem
+//
+// Exit codes tell scripts what went wrong:
+//
+//	0  success
+//	1  unexpected error
+//	2  usage error
+//	3  not found (404)
+//	4  conflict, e.g. a stale version (409)
+//	5  invalid request (400, 422)
+//	6  unauthorized or forbidden (401, 403)
+//	7  rate limited (429)
+//	8  server error (5xx)
+//	9  network error, no response
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+)
+
+// Exit codes, see the package documentation.
+const (
+	exitOK           int = 0
+	exitError        int = 1
+	exitUsage        int = 2
+	exitNotFound     int = 3
+	exitConflict     int = 4
+	exitInvalid      int = 5
+	exitUnauthorized int = 6
+	exitRateLimited  int = 7
+	exitServerError  int = 8
+	exitNetwork      int = 9
+)
+
+const usage string = `Usage:
+  form3ctl accounts list   [--filter attribute=value[,value...]]... [--page n] [--size n] [--all]
+  form3ctl accounts get    <account_id>
+  form3ctl accounts create [-f file.json]
+  form3ctl accounts update <account_id> [-f file.json] [--set attribute=value]...
+  form3ctl accounts delete <account_id> [--version n]
+
+Run form3ctl accounts <command> -h for the flags of a command.
+`
+
+// env looks up environment variables, so tests can provide their own.
+type env func(key string) string
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr, os.Getenv))
+}
+
+// run runs form3ctl with args and returns its exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer, getenv env) int {
+	if len(args) < 2 || args[0] != "accounts" {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+
+	cmd := &accountsCommand{stdin: stdin, stdout: stdout, stderr: stderr, getenv: getenv}
+
+	var err error
+	switch args[1] {
+	case "list":
+		err = cmd.list(args[2:])
+	case "get":
+		err = cmd.get(args[2:])
+	case "create":
+		err = cmd.create(args[2:])
+	case "update":
+		err = cmd.update(args[2:])
+	case "delete":
+		err = cmd.delete(args[2:])
+	default:
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+
+	if err != nil {
+		fmt.Fprintln(stderr, "form3ctl:", err)
+	}
+	return exitCode(err)
+}
+
+// usageError is an error in the command line.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func usagef(format string, args ...interface{}) error {
+	return &usageError{fmt.Sprintf(format, args...)}
+}
+
+// apiError is an error response from the API.
+type apiError struct {
+	status int
+	err    error
+}
+
+func (e *apiError) Error() string {
+	return e.err.Error()
+}
+
+func (e *apiError) Unwrap() error {
+	return e.err
+}
+
+// checkAPI classifies the error of a call to the API by the response it got, if any.
+func checkAPI(res *http.Response, err error) error {
+	if err == nil {
+		return nil
+	}
+	if res == nil {
+		return &networkError{err}
+	}
+
+	// Add the API's explanation to the status, e.g. "409 Conflict: invalid version"
+	var body struct {
+		ErrorMessage string `json:"error_message"`
+	}
+	if raw, rawErr := form3.RawJSON(res); rawErr == nil && json.Unmarshal(raw, &body) == nil && body.ErrorMessage != "" {
+		err = fmt.Errorf("%w: %s", err, body.ErrorMessage)
+	}
+	return &apiError{status: res.StatusCode, err: err}
+}
+
+// networkError is an error reaching the API.
+type networkError struct {
+	err error
+}
+
+func (e *networkError) Error() string {
+	return e.err.Error()
+}
+
+func (e *networkError) Unwrap() error {
+	return e.err
+}
+
+// exitCode returns the exit code reporting err.
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		return exitUsage
+	}
+
+	var netErr *networkError
+	if errors.As(err, &netErr) {
+		return exitNetwork
+	}
+
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		return exitError
+	}
+
+	switch {
+	case apiErr.status == http.StatusNotFound:
+		return exitNotFound
+	case apiErr.status == http.StatusConflict:
+		return exitConflict
+	case apiErr.status == http.StatusBadRequest || apiErr.status == http.StatusUnprocessableEntity:
+		return exitInvalid
+	case apiErr.status == http.StatusUnauthorized || apiErr.status == http.StatusForbidden:
+		return exitUnauthorized
+	case apiErr.status == http.StatusTooManyRequests:
+		return exitRateLimited
+	case apiErr.status >= 500:
+		return exitServerError
+	}
+	return exitError
+}
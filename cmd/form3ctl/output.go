@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+)
+
+// columns of the table and csv outputs.
+var columns = []string{"ID", "COUNTRY", "BANK ID", "BANK ID CODE", "ACCOUNT NUMBER", "IBAN", "BIC", "VERSION"}
+
+// checkFormat checks the -o flag before any request is made.
+func checkFormat(format string) error {
+	switch format {
+	case "table", "json", "yaml", "csv":
+		return nil
+	}
+	return usagef("unknown output format %q, want table, json, yaml or csv", format)
+}
+
+// writeAccounts writes accounts to w in format. A single account is written as an object rather than a list
+// in the json and yaml formats.
+func writeAccounts(w io.Writer, format string, accounts []form3.Account, single bool) error {
+	var v interface{} = accounts
+	if single {
+		v = accounts[0]
+	}
+
+	switch format {
+	case "json":
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+
+	case "yaml":
+		// Go through JSON so the YAML has the API's member names, and Extra members
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		var generic interface{}
+		if err := json.Unmarshal(b, &generic); err != nil {
+			return err
+		}
+		b, err = yaml.Marshal(generic)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write(columns)
+		for _, account := range accounts {
+			cw.Write(row(account))
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	writeRow(tw, columns)
+	for _, account := range accounts {
+		writeRow(tw, row(account))
+	}
+	return tw.Flush()
+}
+
+func row(account form3.Account) []string {
+	a := account.Attributes
+	return []string{account.ID, a.Country, a.BankID, a.BankIDCode, a.AccountNumber, a.Iban, a.Bic, strconv.Itoa(account.Version)}
+}
+
+func writeRow(w io.Writer, cells []string) {
+	for i, cell := range cells {
+		if i > 0 {
+			fmt.Fprint(w, "\t")
+		}
+		if cell == "" {
+			cell = "-"
+		}
+		fmt.Fprint(w, cell)
+	}
+	fmt.Fprintln(w)
+}
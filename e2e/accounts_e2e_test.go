@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+	"github.com/form3tech-oss/interview-accountapi/form3/form3test/factory"
 )
 
 func Test_Run_e2e(t *testing.T) {
@@ -28,12 +29,11 @@ func Test_Run_e2e(t *testing.T) {
 		return
 	}
 
-	// An improved testing approach would generate these dynamically.
-	uuid := "158f775c-4ecd-4861-b33d-30df9a29de78"
-	uuid2 := "258f775c-4ecd-4861-b33d-30df9a29de78"
+	f := factory.New()
 
 	// Create a valid account
-	newAccount := generateAccount(uuid)
+	newAccount := generateAccount(f)
+	uuid := newAccount.ID
 	account, res, err := client.Accounts().Create(context.Background(), newAccount)
 	if err != nil {
 		t.Error(err)
@@ -64,7 +64,8 @@ func Test_Run_e2e(t *testing.T) {
 	}
 
 	// Create another valid account
-	newAccount2 := generateAccount(uuid2)
+	newAccount2 := generateAccount(f)
+	uuid2 := newAccount2.ID
 	account, res, err = client.Accounts().Create(context.Background(), newAccount2)
 	if err != nil {
 		t.Error("Error creating account", err)
@@ -149,15 +150,7 @@ func Test_Run_e2e(t *testing.T) {
 	// - All object attributes (e.g. https://github.com/google/go-github/blob/e5d8dd691c294eb6373a3dd5f58bec1e0d2ed3b1/github/reactions_test.go#L102-L105)
 }
 
-func generateAccount(uuid string) *form3.Account {
-	return &form3.Account{
-		ID:             uuid,
-		OrganisationID: "358f775b-4ecd-4861-b33d-30df9a29de78",
-		Type:           "accounts",
-		Version:        0,
-		Attributes: form3.AccountAttributes{
-			Country: "GB",
-			BankID:  "1112223",
-		},
-	}
+func generateAccount(f *factory.Factory) *form3.Account {
+	account := f.Account().Country("GB").Build()
+	return &account
 }
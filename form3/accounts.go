@@ -2,42 +2,101 @@ package form3
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"net/url"
-	"strconv"
+	"time"
 )
 
 const (
 	accountsPath string = "/organisation/accounts"
 )
 
+var (
+	// ErrBankIDNotRegistered is returned by CheckRegistration when an account's bank ID is not registered for its organisation.
+	ErrBankIDNotRegistered = errors.New("bank_id is not registered for organisation")
+	// ErrBicNotRegistered is returned by CheckRegistration when an account's BIC is not registered for its organisation.
+	ErrBicNotRegistered = errors.New("bic is not registered for organisation")
+)
+
 // Account represents a bank account that is registered with Form3. It is used to validate and allocate inbound payments.
 type Account struct {
-	Attributes     AccountAttributes `json:"attributes"`
-	ID             string            `json:"id"`
-	OrganisationID string            `json:"organisation_id"`
-	Type           string            `json:"type"`
-	Version        int               `json:"version"`
+	Attributes     AccountAttributes          `json:"attributes"`
+	ID             string                     `json:"id"`
+	OrganisationID string                     `json:"organisation_id"`
+	Type           string                     `json:"type"`
+	Version        int                        `json:"version"`
+	Relationships  map[string]Relationship    `json:"relationships,omitempty"`
+	Extra          map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the Account, keeping members not known to this client in Extra.
+func (a *Account) UnmarshalJSON(b []byte) error {
+	type account Account
+	extra, err := unmarshalExtra(b, (*account)(a))
+	if err != nil {
+		return err
+	}
+	a.Extra = extra
+	return nil
+}
+
+// MarshalJSON encodes the Account, including the members in Extra.
+func (a Account) MarshalJSON() ([]byte, error) {
+	type account Account
+	return marshalExtra(account(a), a.Extra)
+}
+
+// Related returns the named relationship of the account, e.g. "master_account".
+func (a *Account) Related(name string) Relationship {
+	return a.Relationships[name]
+}
+
+func (a *Account) resolveIncluded(included *Included) {
+	resolveRelationships(a.Relationships, included)
 }
 
 // AccountAttributes represents attributes of an Account
 type AccountAttributes struct {
-	Country                     string   `json:"country"`
-	BaseCurrency                string   `json:"base_currency,omitempty"`
-	AccountNumber               string   `json:"account_number,omitempty"`
-	BankID                      string   `json:"bank_id,omitempty"`
-	BankIDCode                  string   `json:"bank_id_code,omitempty"`
-	Bic                         string   `json:"bic,omitempty"`
-	Iban                        string   `json:"iban,omitempty"`
-	Title                       string   `json:"title,omitempty"`
-	FirstName                   string   `json:"first_name,omitempty"`
-	BankAccountName             string   `json:"bank_account_name,omitempty"`
-	AlternativeBankAccountNames []string `json:"alternative_bank_account_names,omitempty"`
-	AccountClassification       string   `json:"account_classification,omitempty"`
-	JointAccount                bool     `json:"joint_account,omitempty"`
-	AccountMatchingOptOut       bool     `json:"account_matching_opt_out,omitempty"`
-	SecondaryIdentification     string   `json:"secondary_identification,omitempty"`
+	Country                     string                     `json:"country"`
+	BaseCurrency                string                     `json:"base_currency,omitempty"`
+	AccountNumber               string                     `json:"account_number,omitempty"`
+	BankID                      string                     `json:"bank_id,omitempty"`
+	BankIDCode                  string                     `json:"bank_id_code,omitempty"`
+	Bic                         string                     `json:"bic,omitempty"`
+	Iban                        string                     `json:"iban,omitempty"`
+	Title                       string                     `json:"title,omitempty"`
+	FirstName                   string                     `json:"first_name,omitempty"`
+	BankAccountName             string                     `json:"bank_account_name,omitempty"`
+	AlternativeBankAccountNames []string                   `json:"alternative_bank_account_names,omitempty"`
+	AccountClassification       string                     `json:"account_classification,omitempty"`
+	JointAccount                bool                       `json:"joint_account,omitempty"`
+	AccountMatchingOptOut       bool                       `json:"account_matching_opt_out,omitempty"`
+	SecondaryIdentification     string                     `json:"secondary_identification,omitempty"`
+	Extra                       map[string]json.RawMessage `json:"-"`
+}
+
+// Currency returns BaseCurrency as a Currency, for the currency-aware methods of Amount.
+func (a AccountAttributes) Currency() Currency {
+	return Currency(a.BaseCurrency)
+}
+
+// UnmarshalJSON decodes the AccountAttributes, keeping members not known to this client in Extra.
+func (a *AccountAttributes) UnmarshalJSON(b []byte) error {
+	type accountAttributes AccountAttributes
+	extra, err := unmarshalExtra(b, (*accountAttributes)(a))
+	if err != nil {
+		return err
+	}
+	a.Extra = extra
+	return nil
+}
+
+// MarshalJSON encodes the AccountAttributes, including the members in Extra.
+func (a AccountAttributes) MarshalJSON() ([]byte, error) {
+	type accountAttributes AccountAttributes
+	return marshalExtra(accountAttributes(a), a.Extra)
 }
 
 // Links -> represents the related links to returned resource(s)
@@ -55,51 +114,27 @@ type Links struct {
 // AccountsService implements a service to manage accounts
 // See https://api-docs.form3.tech/api.html?http#organisation-accounts
 type AccountsService struct {
-	client     *Client
-	pagination Pagination
+	client    *Client
+	resources *ResourceService[Account]
 }
 
 // NewAccountsService creates a new AccountsService.
 func NewAccountsService(client *Client) *AccountsService {
 	builder := &AccountsService{
-		client:     client,
-		pagination: NewPagination(),
+		client:    client,
+		resources: NewResourceService[Account](client, accountsPath),
 	}
 	return builder
 }
 
-type fetchAccountAPIResponse struct {
-	Data  Account `json:"data"`
-	Links Links   `json:"links"`
-}
-
-type listAccountsAPIResponse struct {
-	Data  []Account `json:"data"`
-	Links Links     `json:"links"`
-}
-
-type createAccountsAPIPayload struct {
-	Data Account `json:"data"`
-}
-
 // Fetch -> Get a single account using the account ID.
 //
 // GET /v1/organisation/accounts/{account_id}
-func (s *AccountsService) Fetch(ctx context.Context, id string) (*Account, *http.Response, error) {
-	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
-		Method: "GET",
-		Path:   fmt.Sprintf("%s/%s", accountsPath, id),
-	})
-	if err != nil {
-		return nil, res, err
-	}
-
-	var ret fetchAccountAPIResponse
-	if err := s.client.Decode(res, &ret); err != nil {
-		return nil, res, err
-	}
-
-	return &ret.Data, res, nil
+//
+// Pass WithInclude to have related resources returned alongside the account, e.g. WithInclude("master_account").
+// Decode them from the account's relationships: account.Related("master_account").Decode(&master).
+func (s *AccountsService) Fetch(ctx context.Context, id string, options ...RequestOption) (*Account, *http.Response, error) {
+	return s.resources.Fetch(ctx, id, options...)
 }
 
 // List -> List accounts with the ability to filter and page.
@@ -107,21 +142,13 @@ func (s *AccountsService) Fetch(ctx context.Context, id string) (*Account, *http
 // Multiple values can be set for filters in CSV format, e.g. filter[country]=GB,FR,DE.
 //
 // GET /v1/organisation/accounts?page[number]={page_number}&page[size]={page_size}&filter[{attribute}]={filter_value}
-func (s *AccountsService) List(ctx context.Context) ([]Account, *http.Response, error) {
-	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
-		Method: "GET",
-		Path:   accountsPath,
-		Params: s.pagination.Params(),
-	})
-	if err != nil {
-		return nil, nil, err
-	}
+func (s *AccountsService) List(ctx context.Context, options ...RequestOption) ([]Account, *http.Response, error) {
+	return s.resources.List(ctx, options...)
+}
 
-	var ret listAccountsAPIResponse
-	if err := s.client.Decode(res, &ret); err != nil {
-		return nil, nil, err
-	}
-	return ret.Data, res, nil
+// ListAll -> List every account matching the filters, following all pages from Number onwards.
+func (s *AccountsService) ListAll(ctx context.Context, options ...RequestOption) ([]Account, *http.Response, error) {
+	return s.resources.ListAll(ctx, options...)
 }
 
 // Create -> Register an existing bank account with Form3 or create a new one.
@@ -136,25 +163,26 @@ func (s *AccountsService) List(ctx context.Context) ([]Account, *http.Response,
 // - If an account number is provided but the IBAN is empty, Form3 generates an IBAN if supported by the country.
 // - If only an IBAN is provided, the account number will be left empty.
 // - Note that a given bank_id and bic need to be registered with Form3 and connected to your organisation ID.
+// - CheckRegistration verifies this before creating the account.
+// - If OrganisationID is empty, the client's default organisation (see SetOrganisationID) is used.
+//
 // See https://api-docs.form3.tech/api.html?shell#organisation-accounts-create for further details.
 func (s *AccountsService) Create(ctx context.Context, account *Account) (*Account, *http.Response, error) {
-	data := &createAccountsAPIPayload{Data: *account}
-
-	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
-		Method: "POST",
-		Path:   accountsPath,
-		Body:   data,
-	})
-	if err != nil {
-		return nil, res, err
+	data := *account
+	if data.OrganisationID == "" {
+		data.OrganisationID = s.client.organisationID
 	}
 
-	var ret fetchAccountAPIResponse
-	if err := s.client.Decode(res, &ret); err != nil {
-		return nil, res, err
-	}
+	return s.resources.Create(ctx, &data)
+}
 
-	return &ret.Data, res, nil
+// Update -> Update an existing account. The account must carry its current version.
+//
+// PATCH /v1/organisation/accounts/{account_id}
+//
+// A 409 Conflict is returned if the version is out of date.
+func (s *AccountsService) Update(ctx context.Context, account *Account) (*Account, *http.Response, error) {
+	return s.resources.Update(ctx, account.ID, account)
 }
 
 // Delete -> Delete an account
@@ -167,29 +195,102 @@ func (s *AccountsService) Create(ctx context.Context, account *Account) (*Accoun
 // - 404	Not Found	Specified resource does not exist
 // - 409	Conflict	Specified version incorrect
 func (s *AccountsService) Delete(ctx context.Context, id string, version int) (bool, *http.Response, error) {
-	params := url.Values{}
-	params.Add("version", strconv.Itoa(version))
-
-	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
-		Method: "DELETE",
-		Path:   fmt.Sprintf("%s/%s", accountsPath, id),
-		Params: params,
-	})
+	return s.resources.Delete(ctx, id, version)
+}
+
+// CheckRegistration -> Check that the account's BankID and Bic (where set) are registered for its organisation.
+// Call it before Create to catch the most common cause of a 400 Bad Request.
+//
+// The returned error wraps ErrBankIDNotRegistered or ErrBicNotRegistered when a registration is missing.
+func (s *AccountsService) CheckRegistration(ctx context.Context, account *Account) error {
+	organisationID := account.OrganisationID
+	if organisationID == "" {
+		organisationID = s.client.organisationID
+	}
+
+	if bankID := account.Attributes.BankID; bankID != "" {
+		ok, err := s.client.BankIDs().IsRegistered(ctx, organisationID, bankID, account.Attributes.BankIDCode)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%w: %s (%s) for %s", ErrBankIDNotRegistered, bankID, account.Attributes.BankIDCode, organisationID)
+		}
+	}
+
+	if bic := account.Attributes.Bic; bic != "" {
+		ok, err := s.client.Bics().IsRegistered(ctx, organisationID, bic)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%w: %s for %s", ErrBicNotRegistered, bic, organisationID)
+		}
+	}
+
+	return nil
+}
+
+// AccountChange is a single change in an account's history.
+type AccountChange struct {
+	Time          time.Time
+	ActionedBy    string
+	RecordVersion int
+	// Account is the account as it was after the change, or nil if it was deleted.
+	Account *Account
+	// Diffs are the fields that changed. For the first change they are every field that was set.
+	Diffs []FieldDiff
+}
+
+// History -> List every change made to an account, oldest first, with the fields that changed between versions.
+//
+// GET /v1/audit/entries?filter[record_type]=accounts&filter[record_id]={account_id}
+func (s *AccountsService) History(ctx context.Context, id string) ([]AccountChange, *http.Response, error) {
+	entries, res, err := s.client.Audit().RecordType("accounts").RecordID(id).Size(100).ListAll(ctx)
 	if err != nil {
-		return false, res, err
+		return nil, res, err
+	}
+	sortAuditEntries(entries)
+
+	changes := make([]AccountChange, 0, len(entries))
+	for _, entry := range entries {
+		diffs, err := diffJSON(entry.Attributes.BeforeData, entry.Attributes.AfterData)
+		if err != nil {
+			return nil, res, err
+		}
+
+		change := AccountChange{
+			Time:          entry.Attributes.ActionTime,
+			ActionedBy:    entry.Attributes.ActionedBy,
+			RecordVersion: entry.Attributes.RecordVersion,
+			Diffs:         diffs,
+		}
+		if len(entry.Attributes.AfterData) > 0 && string(entry.Attributes.AfterData) != "null" {
+			change.Account = &Account{}
+			if err := json.Unmarshal(entry.Attributes.AfterData, change.Account); err != nil {
+				return nil, res, err
+			}
+		}
+		changes = append(changes, change)
 	}
 
-	return true, res, nil
+	return changes, res, nil
 }
 
 // Number -> page number requested. Defaults to 0.
-func (s *AccountsService) Number(number int) *AccountsService {
-	s.pagination.Number = number
+func (s *AccountsService) Number(number int) AccountsAPI {
+	s.resources.Number(number)
 	return s
 }
 
 // Size -> size is the max number of resources to return. Defaults to 10.
-func (s *AccountsService) Size(size int) *AccountsService {
-	s.pagination.Size = size
+func (s *AccountsService) Size(size int) AccountsAPI {
+	s.resources.Size(size)
+	return s
+}
+
+// Filter -> only list accounts whose attribute matches one of values, e.g. Filter("country", "GB", "FR", "DE").
+func (s *AccountsService) Filter(attribute string, values ...string) AccountsAPI {
+	s.resources.Filter(attribute, values...)
 	return s
 }
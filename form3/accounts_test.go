@@ -182,6 +182,11 @@ func testClient(path string, statusCode int, responseBody string) (*Client, *htt
 		w.Write([]byte(responseBody))
 	})
 
+	return testClientFor(srv), srv
+}
+
+// testClientFor returns a client pointed at srv with logging silenced.
+func testClientFor(srv *httptest.Server) *Client {
 	// httptest.Server returns url as string (https://golang.org/pkg/net/http/httptest/#Server)
 	u, _ := url.Parse(srv.URL)
 
@@ -193,7 +198,7 @@ func testClient(path string, statusCode int, responseBody string) (*Client, *htt
 	client.errorLog.SetOutput(ioutil.Discard)
 	client.infoLog.SetOutput(ioutil.Discard)
 
-	return client, srv
+	return client
 }
 
 var accountJSON = `{
@@ -0,0 +1,300 @@
+package form3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Amount is an exact decimal amount of money, e.g. "100.21".
+// Form3 carries amounts as decimal strings; Amount preserves them without float rounding.
+// Amounts are immutable: arithmetic returns a new Amount. The zero value is 0.
+//
+// An Amount does not carry its currency. Use the currency-aware methods (NewAmountFromMinorUnits,
+// MinorUnits, Round, ValidFor and Format) with the currency of the resource it belongs to,
+// e.g. AccountAttributes.Currency().
+type Amount struct {
+	unscaled *big.Int // value * 10^scale
+	scale    int32    // number of digits after the decimal point
+}
+
+// ParseAmount parses a decimal string such as "100.21" or "-5".
+func ParseAmount(s string) (Amount, error) {
+	str := strings.TrimSpace(s)
+
+	neg := false
+	switch {
+	case strings.HasPrefix(str, "-"):
+		neg = true
+		str = str[1:]
+	case strings.HasPrefix(str, "+"):
+		str = str[1:]
+	}
+
+	whole, frac := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		whole, frac = str[:i], str[i+1:]
+	}
+	if whole == "" && frac == "" || !isDigits(whole) || !isDigits(frac) {
+		return Amount{}, fmt.Errorf("invalid amount %q", s)
+	}
+
+	unscaled, ok := new(big.Int).SetString(whole+frac, 10)
+	if !ok {
+		return Amount{}, fmt.Errorf("invalid amount %q", s)
+	}
+	if neg {
+		unscaled.Neg(unscaled)
+	}
+
+	return Amount{unscaled: unscaled, scale: int32(len(frac))}, nil
+}
+
+// NewAmount returns unscaled * 10^-scale, e.g. NewAmount(10021, 2) is 100.21.
+func NewAmount(unscaled int64, scale int) Amount {
+	if scale < 0 {
+		return Amount{unscaled: rescale(big.NewInt(unscaled), int32(-scale))}
+	}
+	return Amount{unscaled: big.NewInt(unscaled), scale: int32(scale)}
+}
+
+// NewAmountFromMinorUnits returns an amount from a count of the currency's minor units,
+// e.g. 10021 GBP pence is 100.21 and 10021 JPY is 10021.
+func NewAmountFromMinorUnits(minor int64, currency Currency) Amount {
+	return NewAmount(minor, currency.MinorUnits())
+}
+
+// MustParseAmount is like ParseAmount but panics if s is not a valid amount. It is intended for constants and tests.
+func MustParseAmount(s string) Amount {
+	a, err := ParseAmount(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// String returns the amount in decimal form, keeping its scale (e.g. "100.10").
+func (a Amount) String() string {
+	digits := a.int().String()
+
+	neg := strings.HasPrefix(digits, "-")
+	digits = strings.TrimPrefix(digits, "-")
+
+	if a.scale > 0 {
+		if pad := int(a.scale) + 1 - len(digits); pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		digits = digits[:len(digits)-int(a.scale)] + "." + digits[len(digits)-int(a.scale):]
+	}
+
+	if neg {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Add returns a + b.
+func (a Amount) Add(b Amount) Amount {
+	x, y, scale := align(a, b)
+	return Amount{unscaled: new(big.Int).Add(x, y), scale: scale}
+}
+
+// Sub returns a - b.
+func (a Amount) Sub(b Amount) Amount {
+	x, y, scale := align(a, b)
+	return Amount{unscaled: new(big.Int).Sub(x, y), scale: scale}
+}
+
+// Neg returns -a.
+func (a Amount) Neg() Amount {
+	return Amount{unscaled: new(big.Int).Neg(a.int()), scale: a.scale}
+}
+
+// Abs returns |a|.
+func (a Amount) Abs() Amount {
+	return Amount{unscaled: new(big.Int).Abs(a.int()), scale: a.scale}
+}
+
+// Mul returns a * b exactly. The scale of the result is the sum of the scales, e.g. "1.50" * "0.015" has scale 5.
+func (a Amount) Mul(b Amount) Amount {
+	return Amount{unscaled: new(big.Int).Mul(a.int(), b.int()), scale: a.scale + b.scale}
+}
+
+// MulInt returns a * n.
+func (a Amount) MulInt(n int64) Amount {
+	return Amount{unscaled: new(big.Int).Mul(a.int(), big.NewInt(n)), scale: a.scale}
+}
+
+// Split divides a into n parts at the given scale that differ by at most one unit of that scale and sum exactly to a,
+// e.g. "100.00" split 3 ways at scale 2 is "33.34", "33.33", "33.33". a is first rounded to scale.
+func (a Amount) Split(n int, scale int) []Amount {
+	if n <= 0 {
+		return nil
+	}
+
+	total := a.RoundTo(scale).int()
+	quotient, remainder := new(big.Int).QuoRem(total, big.NewInt(int64(n)), new(big.Int))
+
+	// Spread the remainder one unit at a time, in the direction of its sign.
+	unit := big.NewInt(int64(remainder.Sign()))
+	left := new(big.Int).Abs(remainder).Int64()
+
+	parts := make([]Amount, n)
+	for i := range parts {
+		part := new(big.Int).Set(quotient)
+		if int64(i) < left {
+			part.Add(part, unit)
+		}
+		parts[i] = Amount{unscaled: part, scale: int32(scale)}
+	}
+	return parts
+}
+
+// RoundTo returns a rounded to the given number of decimal places (at least 0), rounding halves away from zero.
+// If a already has no more places than that, it is returned at the requested scale unchanged in value.
+func (a Amount) RoundTo(places int) Amount {
+	if places < 0 {
+		places = 0
+	}
+	target := int32(places)
+	if a.scale <= target {
+		return Amount{unscaled: rescale(a.int(), target-a.scale), scale: target}
+	}
+
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(a.scale-target)), nil)
+	quotient, remainder := new(big.Int).QuoRem(a.int(), divisor, new(big.Int))
+
+	// Round half away from zero: compare twice the remainder with the divisor.
+	twice := new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2))
+	if twice.Cmp(divisor) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(a.int().Sign())))
+	}
+
+	return Amount{unscaled: quotient, scale: target}
+}
+
+// Round returns a rounded to the currency's minor units, e.g. "1.005" rounds to "1.01" in GBP and "1" in JPY.
+func (a Amount) Round(currency Currency) Amount {
+	return a.RoundTo(currency.MinorUnits())
+}
+
+// ErrAmountPrecision is returned when an amount has more decimal places than its currency allows.
+var ErrAmountPrecision = errors.New("amount has more decimal places than the currency allows")
+
+// ValidFor returns an error wrapping ErrAmountPrecision if a cannot be expressed exactly in the currency's minor units.
+// Trailing zeros are allowed, so "100.10" is valid for GBP but "100.101" is not.
+func (a Amount) ValidFor(currency Currency) error {
+	if a.Round(currency).Cmp(a) != 0 {
+		return fmt.Errorf("%w: %s %s has at most %d", ErrAmountPrecision, a, currency, currency.MinorUnits())
+	}
+	return nil
+}
+
+// MinorUnits returns a as a count of the currency's minor units, e.g. "100.21" GBP is 10021.
+// It returns an error if a is not exact in the currency or does not fit in an int64.
+func (a Amount) MinorUnits(currency Currency) (int64, error) {
+	if err := a.ValidFor(currency); err != nil {
+		return 0, err
+	}
+
+	minor := a.Round(currency).int()
+	if !minor.IsInt64() {
+		return 0, fmt.Errorf("amount %s %s overflows int64 minor units", a, currency)
+	}
+	return minor.Int64(), nil
+}
+
+// Format returns a in decimal form with exactly the currency's minor units, e.g. "100.10" for GBP and "100" for JPY.
+// a is rounded if it has more places than the currency allows.
+func (a Amount) Format(currency Currency) string {
+	return a.Round(currency).String()
+}
+
+// Scale returns the number of digits after the decimal point in a's decimal form.
+func (a Amount) Scale() int {
+	return int(a.scale)
+}
+
+// Cmp compares a and b and returns -1 if a < b, 0 if a == b and +1 if a > b. Scale is ignored, so "1.0" equals "1".
+func (a Amount) Cmp(b Amount) int {
+	x, y, _ := align(a, b)
+	return x.Cmp(y)
+}
+
+// Sign returns -1, 0 or +1 depending on whether a is negative, zero or positive.
+func (a Amount) Sign() int {
+	return a.int().Sign()
+}
+
+// Equal reports whether a and b have the same value. Scale is ignored, so "1.0" equals "1".
+func (a Amount) Equal(b Amount) bool {
+	return a.Cmp(b) == 0
+}
+
+// IsZero reports whether a is zero.
+func (a Amount) IsZero() bool {
+	return a.Sign() == 0
+}
+
+// MarshalJSON encodes the amount as a JSON string, e.g. "100.21".
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON decodes an amount from a JSON string. A bare JSON number is accepted too.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+
+	parsed, err := ParseAmount(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+func (a Amount) int() *big.Int {
+	if a.unscaled == nil {
+		return new(big.Int)
+	}
+	return a.unscaled
+}
+
+// align returns the unscaled values of a and b at a common scale.
+func align(a, b Amount) (*big.Int, *big.Int, int32) {
+	x, y := a.int(), b.int()
+	switch {
+	case a.scale < b.scale:
+		x = rescale(x, b.scale-a.scale)
+		return x, y, b.scale
+	case b.scale < a.scale:
+		y = rescale(y, a.scale-b.scale)
+		return x, y, a.scale
+	}
+	return x, y, a.scale
+}
+
+// rescale multiplies v by 10^digits.
+func rescale(v *big.Int, digits int32) *big.Int {
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+	return new(big.Int).Mul(v, factor)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
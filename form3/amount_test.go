@@ -0,0 +1,143 @@
+package form3
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func Test_ParseAmount(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected string
+		valid    bool
+	}{
+		{"100.21", "100.21", true},
+		{"0.05", "0.05", true},
+		{"-5", "-5", true},
+		{".5", "0.5", true},
+		{"100.", "100", true},
+		{"1e3", "", false},
+		{"", "", false},
+		{"12.3.4", "", false},
+	}
+
+	for _, tt := range tests {
+		a, err := ParseAmount(tt.in)
+		if tt.valid != (err == nil) {
+			t.Errorf("ParseAmount(%q): expected valid=%v, got err=%v", tt.in, tt.valid, err)
+			continue
+		}
+		if tt.valid && a.String() != tt.expected {
+			t.Errorf("ParseAmount(%q): expected %s, got %s", tt.in, tt.expected, a.String())
+		}
+	}
+}
+
+func Test_AmountArithmetic(t *testing.T) {
+	// 0.1 + 0.2 is famously not 0.3 in floating point.
+	sum := MustParseAmount("0.1").Add(MustParseAmount("0.2"))
+	if sum.Cmp(MustParseAmount("0.3")) != 0 {
+		t.Error("Expected: 0.3", "Got:", sum)
+	}
+
+	diff := MustParseAmount("100").Sub(MustParseAmount("100.21"))
+	if diff.String() != "-0.21" {
+		t.Error("Expected: -0.21", "Got:", diff)
+	}
+
+	if MustParseAmount("1.0").Cmp(MustParseAmount("1")) != 0 {
+		t.Error("Expected: 1.0 == 1")
+	}
+}
+
+func Test_AmountJSON(t *testing.T) {
+	var v struct {
+		Amount Amount `json:"amount"`
+	}
+	if err := json.Unmarshal([]byte(`{"amount": "100.10"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+
+	out, _ := json.Marshal(v)
+	if string(out) != `{"amount":"100.10"}` {
+		t.Error(`Expected: {"amount":"100.10"}`, "Got:", string(out))
+	}
+}
+
+func Test_AmountCurrency(t *testing.T) {
+	tests := []struct {
+		amount    string
+		currency  Currency
+		formatted string
+		minor     int64
+		valid     bool
+	}{
+		{"100.1", GBP, "100.10", 10010, true},
+		{"100.101", GBP, "100.10", 0, false},
+		{"1.005", GBP, "1.01", 0, false},
+		{"-1.005", GBP, "-1.01", 0, false},
+		{"100", JPY, "100", 100, true},
+		{"100.5", JPY, "101", 0, false},
+		{"1.234", BHD, "1.234", 1234, true},
+		{"1.2345", BHD, "1.235", 0, false},
+	}
+
+	for _, tt := range tests {
+		a := MustParseAmount(tt.amount)
+
+		if got := a.Format(tt.currency); got != tt.formatted {
+			t.Errorf("%s %s: expected format %s, got %s", tt.amount, tt.currency, tt.formatted, got)
+		}
+
+		minor, err := a.MinorUnits(tt.currency)
+		if tt.valid != (err == nil) {
+			t.Errorf("%s %s: expected valid=%v, got err=%v", tt.amount, tt.currency, tt.valid, err)
+		}
+		if tt.valid && minor != tt.minor {
+			t.Errorf("%s %s: expected %d minor units, got %d", tt.amount, tt.currency, tt.minor, minor)
+		}
+	}
+
+	if got := NewAmountFromMinorUnits(10021, GBP).String(); got != "100.21" {
+		t.Error("Expected: 100.21", "Got:", got)
+	}
+	if got := NewAmountFromMinorUnits(10021, JPY).String(); got != "10021" {
+		t.Error("Expected: 10021", "Got:", got)
+	}
+}
+
+func Test_AmountSplit(t *testing.T) {
+	parts := MustParseAmount("100.00").Split(3, GBP.MinorUnits())
+
+	var sum Amount
+	var got []string
+	for _, part := range parts {
+		sum = sum.Add(part)
+		got = append(got, part.String())
+	}
+
+	if !sum.Equal(MustParseAmount("100")) {
+		t.Error("Expected: parts to sum to 100", "Got:", sum)
+	}
+
+	expected := "[33.34 33.33 33.33]"
+	if fmt.Sprint(got) != expected {
+		t.Error("Expected:", expected, "Got:", got)
+	}
+}
+
+func Test_AccountBaseCurrency(t *testing.T) {
+	var account Account
+	if err := json.Unmarshal([]byte(`{"attributes": {"base_currency": "JPY"}}`), &account); err != nil {
+		t.Fatal(err)
+	}
+
+	if account.Attributes.Currency() != JPY {
+		t.Error("Expected:", JPY, "Got:", account.Attributes.Currency())
+	}
+
+	if account.Attributes.Currency().MinorUnits() != 0 {
+		t.Error("Expected: 0", "Got:", account.Attributes.Currency().MinorUnits())
+	}
+}
@@ -0,0 +1,231 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"time"
+)
+
+const (
+	auditEntriesPath string = "/audit/entries"
+)
+
+// AuditEntry represents a single change made to a Form3 resource.
+type AuditEntry struct {
+	Attributes     AuditEntryAttributes `json:"attributes"`
+	ID             string               `json:"id"`
+	OrganisationID string               `json:"organisation_id"`
+	Type           string               `json:"type"`
+	Version        int                  `json:"version"`
+}
+
+// AuditEntryAttributes represents attributes of an AuditEntry
+type AuditEntryAttributes struct {
+	ActionTime    time.Time `json:"action_time"`
+	ActionedBy    string    `json:"actioned_by"`
+	Description   string    `json:"description,omitempty"`
+	RecordType    string    `json:"record_type"`
+	RecordID      string    `json:"record_id"`
+	RecordVersion int       `json:"record_version"`
+	// BeforeData and AfterData hold the resource as it was before and after the change.
+	BeforeData json.RawMessage `json:"before_data,omitempty"`
+	AfterData  json.RawMessage `json:"after_data,omitempty"`
+}
+
+// FieldDiff describes a change to a single field of a resource between two versions.
+// Path is the dotted JSON path of the field, e.g. "attributes.bank_id".
+type FieldDiff struct {
+	Path   string
+	Before interface{}
+	After  interface{}
+}
+
+// AuditService implements a service to query audit entries
+// See https://api-docs.form3.tech/api.html#audit
+type AuditService struct {
+	client     *Client
+	pagination Pagination
+	filter     url.Values
+}
+
+// NewAuditService creates a new AuditService.
+func NewAuditService(client *Client) *AuditService {
+	return &AuditService{
+		client:     client,
+		pagination: NewPagination(),
+		filter:     url.Values{},
+	}
+}
+
+type listAuditEntriesAPIResponse struct {
+	Data  []AuditEntry `json:"data"`
+	Links Links        `json:"links"`
+}
+
+// List -> List a page of audit entries matching the filters, paged using Number and Size.
+//
+// GET /v1/audit/entries?page[number]={page_number}&page[size]={page_size}&filter[{attribute}]={filter_value}
+func (s *AuditService) List(ctx context.Context) ([]AuditEntry, *http.Response, error) {
+	ret, res, err := s.list(ctx, s.pagination)
+	if err != nil {
+		return nil, res, err
+	}
+	return ret.Data, res, nil
+}
+
+// ListAll -> List every audit entry matching the filters, following all pages from Number onwards.
+func (s *AuditService) ListAll(ctx context.Context) ([]AuditEntry, *http.Response, error) {
+	var entries []AuditEntry
+
+	page := s.pagination
+	for {
+		ret, res, err := s.list(ctx, page)
+		if err != nil {
+			return nil, res, err
+		}
+		entries = append(entries, ret.Data...)
+
+		if lastPage(len(ret.Data), ret.Links) {
+			return entries, res, nil
+		}
+		page.Number++
+	}
+}
+
+// RecordType -> only return entries for resources of this type, e.g. "accounts".
+func (s *AuditService) RecordType(recordType string) AuditAPI {
+	s.filter.Set("filter[record_type]", recordType)
+	return s
+}
+
+// RecordID -> only return entries for the resource with this ID, e.g. an Account.ID.
+func (s *AuditService) RecordID(recordID string) AuditAPI {
+	s.filter.Set("filter[record_id]", recordID)
+	return s
+}
+
+// Actor -> only return entries for changes made by this user ID.
+func (s *AuditService) Actor(userID string) AuditAPI {
+	s.filter.Set("filter[actioned_by]", userID)
+	return s
+}
+
+// Between -> only return entries for changes made in the time range [from, to). Zero times leave that end open.
+func (s *AuditService) Between(from, to time.Time) AuditAPI {
+	if !from.IsZero() {
+		s.filter.Set("filter[action_time_from]", from.UTC().Format(time.RFC3339))
+	}
+	if !to.IsZero() {
+		s.filter.Set("filter[action_time_to]", to.UTC().Format(time.RFC3339))
+	}
+	return s
+}
+
+// Number -> page number requested. Defaults to 0.
+func (s *AuditService) Number(number int) AuditAPI {
+	s.pagination.Number = number
+	return s
+}
+
+// Size -> size is the max number of resources to return. Defaults to 10.
+func (s *AuditService) Size(size int) AuditAPI {
+	s.pagination.Size = size
+	return s
+}
+
+func (s *AuditService) list(ctx context.Context, page Pagination) (*listAuditEntriesAPIResponse, *http.Response, error) {
+	params := page.Params()
+	for key, values := range s.filter {
+		params[key] = values
+	}
+
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "GET",
+		Path:   auditEntriesPath,
+		Params: params,
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret listAuditEntriesAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+	return &ret, res, nil
+}
+
+// sortAuditEntries orders entries chronologically, by record version where action times are equal.
+func sortAuditEntries(entries []AuditEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].Attributes, entries[j].Attributes
+		if !a.ActionTime.Equal(b.ActionTime) {
+			return a.ActionTime.Before(b.ActionTime)
+		}
+		return a.RecordVersion < b.RecordVersion
+	})
+}
+
+// diffJSON compares two JSON documents field by field. Arrays are compared as a whole.
+func diffJSON(before, after json.RawMessage) ([]FieldDiff, error) {
+	b, err := flattenJSON(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := flattenJSON(after)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []FieldDiff
+	for path, value := range b {
+		if other, ok := a[path]; !ok || !reflect.DeepEqual(value, other) {
+			diffs = append(diffs, FieldDiff{Path: path, Before: value, After: a[path]})
+		}
+	}
+	for path, value := range a {
+		if _, ok := b[path]; !ok {
+			diffs = append(diffs, FieldDiff{Path: path, After: value})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Path < diffs[j].Path })
+	return diffs, nil
+}
+
+func flattenJSON(data json.RawMessage) (map[string]interface{}, error) {
+	flat := map[string]interface{}{}
+	if len(data) == 0 {
+		return flat, nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+
+	flatten("", v, flat)
+	return flat, nil
+}
+
+func flatten(prefix string, v interface{}, flat map[string]interface{}) {
+	object, ok := v.(map[string]interface{})
+	if !ok {
+		if prefix != "" {
+			flat[prefix] = v
+		}
+		return
+	}
+
+	for key, value := range object {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		flatten(path, value, flat)
+	}
+}
@@ -0,0 +1,114 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_ListAllAuditEntries_Success(t *testing.T) {
+	var queries []string
+	srv := serverMock("/v1/audit/entries", func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("page[number]"))
+		if r.URL.Query().Get("filter[actioned_by]") != "user-1" {
+			t.Error("Expected: actioned_by filter", "Got:", r.URL.RawQuery)
+		}
+
+		// Pages are capped at 2 entries, shorter than the size asked for
+		start, end, links := cappedPage(r, 3, 2)
+
+		var data []AuditEntry
+		for i := start; i < end; i++ {
+			data = append(data, AuditEntry{ID: fmt.Sprintf("e%d", i+1)})
+		}
+		json.NewEncoder(w).Encode(listAuditEntriesAPIResponse{Data: data, Links: links})
+	})
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	from := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	entries, _, err := client.Audit().Actor("user-1").Between(from, time.Time{}).Size(5).ListAll(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(entries) != 3 {
+		t.Error("Expected:", 3, "Got:", len(entries))
+	}
+
+	if !reflect.DeepEqual(queries, []string{"0", "1"}) {
+		t.Error("Expected: pages 0 and 1", "Got:", queries)
+	}
+}
+
+func Test_AccountHistory_Success(t *testing.T) {
+	srv := serverMock("/v1/audit/entries", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("filter[record_id]") != "158f775c-4ecd-4861-b33d-30df9a29de78" {
+			t.Error("Expected: record_id filter", "Got:", r.URL.RawQuery)
+		}
+		if r.URL.Query().Get("page[number]") != "0" {
+			fmt.Fprint(w, `{"data": []}`)
+			return
+		}
+		w.Write([]byte(accountAuditEntriesJSON))
+	})
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	history, _, err := client.Accounts().History(context.Background(), "158f775c-4ecd-4861-b33d-30df9a29de78")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(history) != 2 {
+		t.Fatal("Expected:", 2, "Got:", len(history))
+	}
+
+	// Entries are returned newest first and must be reordered.
+	if history[0].RecordVersion != 0 || history[1].RecordVersion != 1 {
+		t.Error("Expected: versions 0 then 1", "Got:", history[0].RecordVersion, history[1].RecordVersion)
+	}
+
+	expected := []FieldDiff{
+		{Path: "attributes.bank_id", Before: "400300", After: "400302"},
+		{Path: "version", Before: float64(0), After: float64(1)},
+	}
+	if !reflect.DeepEqual(history[1].Diffs, expected) {
+		t.Error("Expected:", expected, "Got:", history[1].Diffs)
+	}
+
+	if history[1].Account.Attributes.BankID != "400302" {
+		t.Error("Expected: 400302", "Got:", history[1].Account.Attributes.BankID)
+	}
+}
+
+var accountAuditEntriesJSON = `{
+	"data": [{
+		"type": "audit_entries",
+		"id": "e2",
+		"attributes": {
+			"action_time": "2020-07-01T09:00:00Z",
+			"actioned_by": "user-2",
+			"record_type": "accounts",
+			"record_id": "158f775c-4ecd-4861-b33d-30df9a29de78",
+			"record_version": 1,
+			"before_data": {"id": "158f775c-4ecd-4861-b33d-30df9a29de78", "version": 0, "attributes": {"country": "GB", "bank_id": "400300"}},
+			"after_data": {"id": "158f775c-4ecd-4861-b33d-30df9a29de78", "version": 1, "attributes": {"country": "GB", "bank_id": "400302"}}
+		}
+	}, {
+		"type": "audit_entries",
+		"id": "e1",
+		"attributes": {
+			"action_time": "2020-06-30T15:16:30Z",
+			"actioned_by": "user-1",
+			"record_type": "accounts",
+			"record_id": "158f775c-4ecd-4861-b33d-30df9a29de78",
+			"record_version": 0,
+			"after_data": {"id": "158f775c-4ecd-4861-b33d-30df9a29de78", "version": 0, "attributes": {"country": "GB", "bank_id": "400300"}}
+		}
+	}]
+}`
@@ -0,0 +1,196 @@
+package form3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	bankIDsPath string = "/organisation/bankids"
+)
+
+// BankID represents a bank ID (e.g. a UK sort code) registered with Form3 and connected to an organisation.
+type BankID struct {
+	Attributes     BankIDAttributes `json:"attributes"`
+	ID             string           `json:"id"`
+	OrganisationID string           `json:"organisation_id"`
+	Type           string           `json:"type"`
+	Version        int              `json:"version"`
+}
+
+// BankIDAttributes represents attributes of a BankID
+type BankIDAttributes struct {
+	BankID     string `json:"bank_id"`
+	BankIDCode string `json:"bank_id_code"`
+	Country    string `json:"country"`
+}
+
+// BankIDsService implements a service to manage registered bank IDs
+// See https://api-docs.form3.tech/api.html#organisation-bank-ids
+type BankIDsService struct {
+	client     *Client
+	pagination Pagination
+}
+
+// NewBankIDsService creates a new BankIDsService.
+func NewBankIDsService(client *Client) *BankIDsService {
+	return &BankIDsService{
+		client:     client,
+		pagination: NewPagination(),
+	}
+}
+
+type fetchBankIDAPIResponse struct {
+	Data  BankID `json:"data"`
+	Links Links  `json:"links"`
+}
+
+type listBankIDsAPIResponse struct {
+	Data  []BankID `json:"data"`
+	Links Links    `json:"links"`
+}
+
+type createBankIDAPIPayload struct {
+	Data BankID `json:"data"`
+}
+
+// Fetch -> Get a single bank ID registration using its ID.
+//
+// GET /v1/organisation/bankids/{id}
+func (s *BankIDsService) Fetch(ctx context.Context, id string) (*BankID, *http.Response, error) {
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%s", bankIDsPath, id),
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret fetchBankIDAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	return &ret.Data, res, nil
+}
+
+// List -> List registered bank IDs, paged using Number and Size.
+//
+// GET /v1/organisation/bankids?page[number]={page_number}&page[size]={page_size}
+func (s *BankIDsService) List(ctx context.Context) ([]BankID, *http.Response, error) {
+	ret, res, err := s.list(ctx, s.pagination.Params())
+	if err != nil {
+		return nil, res, err
+	}
+	return ret.Data, res, nil
+}
+
+// Create -> Register a bank ID and connect it to an organisation.
+//
+// POST /v1/organisation/bankids
+func (s *BankIDsService) Create(ctx context.Context, bankID *BankID) (*BankID, *http.Response, error) {
+	data := &createBankIDAPIPayload{Data: *bankID}
+	if data.Data.OrganisationID == "" {
+		data.Data.OrganisationID = s.client.organisationID
+	}
+
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "POST",
+		Path:   bankIDsPath,
+		Body:   data,
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret fetchBankIDAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	return &ret.Data, res, nil
+}
+
+// Delete -> Delete a bank ID registration
+//
+// DELETE /v1/organisation/bankids/{id}?version={version}
+//
+// No response body returned.
+func (s *BankIDsService) Delete(ctx context.Context, id string, version int) (bool, *http.Response, error) {
+	params := url.Values{}
+	params.Add("version", strconv.Itoa(version))
+
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("%s/%s", bankIDsPath, id),
+		Params: params,
+	})
+	if err != nil {
+		return false, res, err
+	}
+
+	return true, res, nil
+}
+
+// IsRegistered -> Report whether a bank ID (and bank ID code, if given) is registered for an organisation.
+//
+// GET /v1/organisation/bankids?filter[organisation_id]={organisation_id}&filter[bank_id]={bank_id}
+func (s *BankIDsService) IsRegistered(ctx context.Context, organisationID, bankID, bankIDCode string) (bool, error) {
+	page := Pagination{Number: 0, Size: s.pagination.Size}
+	for {
+		params := page.Params()
+		params.Add("filter[organisation_id]", organisationID)
+		params.Add("filter[bank_id]", bankID)
+
+		ret, _, err := s.list(ctx, params)
+		if err != nil {
+			return false, err
+		}
+
+		for _, r := range ret.Data {
+			if r.OrganisationID != organisationID || r.Attributes.BankID != bankID {
+				continue
+			}
+			if bankIDCode == "" || r.Attributes.BankIDCode == bankIDCode {
+				return true, nil
+			}
+		}
+
+		if lastPage(len(ret.Data), ret.Links) {
+			return false, nil
+		}
+		page.Number++
+	}
+}
+
+// Number -> page number requested. Defaults to 0.
+func (s *BankIDsService) Number(number int) BankIDsAPI {
+	s.pagination.Number = number
+	return s
+}
+
+// Size -> size is the max number of resources to return. Defaults to 10.
+func (s *BankIDsService) Size(size int) BankIDsAPI {
+	s.pagination.Size = size
+	return s
+}
+
+func (s *BankIDsService) list(ctx context.Context, params url.Values) (*listBankIDsAPIResponse, *http.Response, error) {
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "GET",
+		Path:   bankIDsPath,
+		Params: params,
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret listBankIDsAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+	return &ret, res, nil
+}
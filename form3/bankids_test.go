@@ -0,0 +1,110 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_FetchBankID_Success(t *testing.T) {
+	client, srv := testClient("/v1/organisation/bankids/6d5e1e2a-2f3b-4c5d-8e9f-0a1b2c3d4e5f", http.StatusOK, bankIDJSON)
+	defer srv.Close()
+
+	bankID, res, err := client.BankIDs().Fetch(context.Background(), "6d5e1e2a-2f3b-4c5d-8e9f-0a1b2c3d4e5f")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if http.StatusOK != res.StatusCode {
+		t.Error("Expected:", http.StatusOK, "Got:", res.StatusCode)
+	}
+
+	if bankID.Attributes.BankID != "400300" {
+		t.Error("Expected: 400300", "Got:", bankID.Attributes.BankID)
+	}
+}
+
+func Test_CheckRegistration(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/organisation/bankids", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page[number]") != "0" {
+			fmt.Fprint(w, `{"data": []}`)
+			return
+		}
+		fmt.Fprint(w, `{"data": [{"id": "1", "organisation_id": "org", "attributes": {"bank_id": "400300", "bank_id_code": "GBDSC", "country": "GB"}}]}`)
+	})
+	mux.HandleFunc("/v1/organisation/bics", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page[number]") != "0" {
+			fmt.Fprint(w, `{"data": []}`)
+			return
+		}
+		fmt.Fprint(w, `{"data": [{"id": "2", "organisation_id": "org", "attributes": {"bic": "NWBKGB22"}}]}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	tests := []struct {
+		name       string
+		attributes AccountAttributes
+		expected   error
+	}{
+		{"registered", AccountAttributes{BankID: "400300", BankIDCode: "GBDSC", Bic: "NWBKGB22"}, nil},
+		{"unregistered bank id", AccountAttributes{BankID: "400301", BankIDCode: "GBDSC"}, ErrBankIDNotRegistered},
+		{"wrong bank id code", AccountAttributes{BankID: "400300", BankIDCode: "GBXXX"}, ErrBankIDNotRegistered},
+		{"unregistered bic", AccountAttributes{Bic: "BARCGB22"}, ErrBicNotRegistered},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := &Account{OrganisationID: "org", Attributes: tt.attributes}
+
+			err := client.Accounts().CheckRegistration(context.Background(), account)
+			if !errors.Is(err, tt.expected) {
+				t.Error("Expected:", tt.expected, "Got:", err)
+			}
+		})
+	}
+}
+
+func Test_IsBankIDRegistered_SizeAboveCap_Success(t *testing.T) {
+	srv := serverMock("/v1/organisation/bankids", func(w http.ResponseWriter, r *http.Request) {
+		start, end, links := cappedPage(r, 5, 2)
+
+		var data []BankID
+		for i := start; i < end; i++ {
+			data = append(data, BankID{ID: fmt.Sprint(i), OrganisationID: "org", Attributes: BankIDAttributes{BankID: "400300", BankIDCode: fmt.Sprintf("CODE%d", i)}})
+		}
+		json.NewEncoder(w).Encode(listBankIDsAPIResponse{Data: data, Links: links})
+	})
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	// The registration is on the last page, past a page shorter than the size asked for
+	registered, err := client.BankIDs().Size(3).IsRegistered(context.Background(), "org", "400300", "CODE4")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !registered {
+		t.Error("Expected: registered", "Got:", registered)
+	}
+}
+
+var bankIDJSON = `{
+	"data": {
+		"type": "bankids",
+		"id": "6d5e1e2a-2f3b-4c5d-8e9f-0a1b2c3d4e5f",
+		"version": 0,
+		"organisation_id": "158f775d-4ecd-4861-b33d-30df9a29de78",
+		"attributes": {
+			"bank_id": "400300",
+			"bank_id_code": "GBDSC",
+			"country": "GB"
+		}
+	}
+}`
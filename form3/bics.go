@@ -0,0 +1,212 @@
+package form3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	bicsPath string = "/organisation/bics"
+)
+
+// Bic represents a SWIFT BIC registered with Form3 and connected to an organisation.
+type Bic struct {
+	Attributes     BicAttributes `json:"attributes"`
+	ID             string        `json:"id"`
+	OrganisationID string        `json:"organisation_id"`
+	Type           string        `json:"type"`
+	Version        int           `json:"version"`
+}
+
+// BicAttributes represents attributes of a Bic
+type BicAttributes struct {
+	Bic string `json:"bic"`
+}
+
+// BicsService implements a service to manage registered BICs
+// See https://api-docs.form3.tech/api.html#organisation-bics
+type BicsService struct {
+	client     *Client
+	pagination Pagination
+}
+
+// NewBicsService creates a new BicsService.
+func NewBicsService(client *Client) *BicsService {
+	return &BicsService{
+		client:     client,
+		pagination: NewPagination(),
+	}
+}
+
+type fetchBicAPIResponse struct {
+	Data  Bic   `json:"data"`
+	Links Links `json:"links"`
+}
+
+type listBicsAPIResponse struct {
+	Data  []Bic `json:"data"`
+	Links Links `json:"links"`
+}
+
+type createBicAPIPayload struct {
+	Data Bic `json:"data"`
+}
+
+// Fetch -> Get a single BIC registration using its ID.
+//
+// GET /v1/organisation/bics/{id}
+func (s *BicsService) Fetch(ctx context.Context, id string) (*Bic, *http.Response, error) {
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%s", bicsPath, id),
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret fetchBicAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	return &ret.Data, res, nil
+}
+
+// List -> List registered BICs, paged using Number and Size.
+//
+// GET /v1/organisation/bics?page[number]={page_number}&page[size]={page_size}
+func (s *BicsService) List(ctx context.Context) ([]Bic, *http.Response, error) {
+	ret, res, err := s.list(ctx, s.pagination.Params())
+	if err != nil {
+		return nil, res, err
+	}
+	return ret.Data, res, nil
+}
+
+// Create -> Register a BIC and connect it to an organisation.
+//
+// POST /v1/organisation/bics
+func (s *BicsService) Create(ctx context.Context, bic *Bic) (*Bic, *http.Response, error) {
+	data := &createBicAPIPayload{Data: *bic}
+	if data.Data.OrganisationID == "" {
+		data.Data.OrganisationID = s.client.organisationID
+	}
+
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "POST",
+		Path:   bicsPath,
+		Body:   data,
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret fetchBicAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	return &ret.Data, res, nil
+}
+
+// Delete -> Delete a BIC registration
+//
+// DELETE /v1/organisation/bics/{id}?version={version}
+//
+// No response body returned.
+func (s *BicsService) Delete(ctx context.Context, id string, version int) (bool, *http.Response, error) {
+	params := url.Values{}
+	params.Add("version", strconv.Itoa(version))
+
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("%s/%s", bicsPath, id),
+		Params: params,
+	})
+	if err != nil {
+		return false, res, err
+	}
+
+	return true, res, nil
+}
+
+// IsRegistered -> Report whether a BIC is registered for an organisation.
+// The 8 character form of a BIC matches its 11 character form for the primary office, e.g. NWBKGB2L and NWBKGB2LXXX.
+//
+// GET /v1/organisation/bics?filter[organisation_id]={organisation_id}&filter[bic]={bic}
+func (s *BicsService) IsRegistered(ctx context.Context, organisationID, bic string) (bool, error) {
+	bic = normaliseBic(bic)
+
+	page := Pagination{Number: 0, Size: s.pagination.Size}
+	for {
+		params := page.Params()
+		params.Add("filter[organisation_id]", organisationID)
+		params.Add("filter[bic]", strings.Join(bicForms(bic), ","))
+
+		ret, _, err := s.list(ctx, params)
+		if err != nil {
+			return false, err
+		}
+
+		for _, r := range ret.Data {
+			if r.OrganisationID == organisationID && normaliseBic(r.Attributes.Bic) == bic {
+				return true, nil
+			}
+		}
+
+		if lastPage(len(ret.Data), ret.Links) {
+			return false, nil
+		}
+		page.Number++
+	}
+}
+
+// normaliseBic returns the 11 character form of a BIC, an 8 character BIC being that of the primary office (XXX).
+func normaliseBic(bic string) string {
+	bic = strings.ToUpper(strings.TrimSpace(bic))
+	if len(bic) == 8 {
+		return bic + "XXX"
+	}
+	return bic
+}
+
+// bicForms returns the forms a normalised BIC may be registered under.
+func bicForms(bic string) []string {
+	if len(bic) == 11 && strings.HasSuffix(bic, "XXX") {
+		return []string{bic[:8], bic}
+	}
+	return []string{bic}
+}
+
+// Number -> page number requested. Defaults to 0.
+func (s *BicsService) Number(number int) BicsAPI {
+	s.pagination.Number = number
+	return s
+}
+
+// Size -> size is the max number of resources to return. Defaults to 10.
+func (s *BicsService) Size(size int) BicsAPI {
+	s.pagination.Size = size
+	return s
+}
+
+func (s *BicsService) list(ctx context.Context, params url.Values) (*listBicsAPIResponse, *http.Response, error) {
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "GET",
+		Path:   bicsPath,
+		Params: params,
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret listBicsAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+	return &ret, res, nil
+}
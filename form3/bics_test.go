@@ -0,0 +1,74 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func Test_IsBicRegistered_Success(t *testing.T) {
+	srv := serverMock("/v1/organisation/bics", func(w http.ResponseWriter, r *http.Request) {
+		// Filters match exact values, one of the comma separated ones
+		var data []Bic
+		for _, registered := range []string{"NWBKGB2LXXX", "BARCGB22", "HBUKGB4B123"} {
+			for _, value := range strings.Split(r.URL.Query().Get("filter[bic]"), ",") {
+				if value == registered && r.URL.Query().Get("page[number]") == "0" {
+					data = append(data, Bic{ID: registered, OrganisationID: "org", Attributes: BicAttributes{Bic: registered}})
+				}
+			}
+		}
+		json.NewEncoder(w).Encode(listBicsAPIResponse{Data: data})
+	})
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	tests := map[string]bool{
+		"NWBKGB2LXXX": true,
+		"NWBKGB2L":    true,
+		"nwbkgb2l":    true,
+		"BARCGB22":    true,
+		"BARCGB22XXX": true,
+		"HBUKGB4B123": true,
+		"HBUKGB4B":    false,
+		"NWBKGB2L123": false,
+	}
+	for bic, expected := range tests {
+		registered, err := client.Bics().IsRegistered(context.Background(), "org", bic)
+		if err != nil {
+			t.Error(err)
+		}
+		if registered != expected {
+			t.Error(bic, "Expected:", expected, "Got:", registered)
+		}
+	}
+}
+
+func Test_IsBicRegistered_SizeAboveCap_Success(t *testing.T) {
+	srv := serverMock("/v1/organisation/bics", func(w http.ResponseWriter, r *http.Request) {
+		start, end, links := cappedPage(r, 5, 2)
+
+		var data []Bic
+		for i := start; i < end; i++ {
+			data = append(data, Bic{ID: fmt.Sprint(i), OrganisationID: "other", Attributes: BicAttributes{Bic: "NWBKGB2LXXX"}})
+		}
+		if end == 5 {
+			data[len(data)-1].OrganisationID = "org"
+		}
+		json.NewEncoder(w).Encode(listBicsAPIResponse{Data: data, Links: links})
+	})
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	// The registration is on the last page, past a page shorter than the size asked for
+	registered, err := client.Bics().Size(3).IsRegistered(context.Background(), "org", "NWBKGB2L")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !registered {
+		t.Error("Expected: registered", "Got:", registered)
+	}
+}
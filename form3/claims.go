@@ -0,0 +1,317 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const (
+	claimsPath   string = "/transaction/claims"
+	paymentsPath string = "/transaction/payments"
+)
+
+// ClaimReason is the reason a claim is raised against a credit transfer.
+type ClaimReason string
+
+// Supported claim reasons.
+const (
+	ClaimReasonNonReceipt          ClaimReason = "non_receipt"
+	ClaimReasonValueDateCorrection ClaimReason = "value_date_correction"
+	ClaimReasonIncorrectAmount     ClaimReason = "incorrect_amount"
+	ClaimReasonDuplicate           ClaimReason = "duplicate"
+	ClaimReasonFraud               ClaimReason = "fraud"
+)
+
+// Valid reports whether r is one of the supported claim reasons.
+func (r ClaimReason) Valid() bool {
+	switch r {
+	case ClaimReasonNonReceipt, ClaimReasonValueDateCorrection, ClaimReasonIncorrectAmount, ClaimReasonDuplicate, ClaimReasonFraud:
+		return true
+	}
+	return false
+}
+
+// Claim represents a claim raised with Form3 about a credit transfer, e.g. when a customer disputes a payment.
+type Claim struct {
+	Attributes     ClaimAttributes    `json:"attributes"`
+	ID             string             `json:"id"`
+	OrganisationID string             `json:"organisation_id"`
+	Type           string             `json:"type"`
+	Version        int                `json:"version"`
+	Relationships  ClaimRelationships `json:"relationships"`
+}
+
+// ClaimAttributes represents attributes of a Claim
+type ClaimAttributes struct {
+	Reason      ClaimReason `json:"reason"`
+	Amount      Amount      `json:"amount"`
+	Currency    Currency    `json:"currency"`
+	Description string      `json:"description,omitempty"`
+	Status      string      `json:"status,omitempty"`
+}
+
+// ClaimRelationships represents the resources related to a Claim
+type ClaimRelationships struct {
+	// Payment is the payment the claim was raised against.
+	Payment Relationship `json:"payment"`
+	// ClaimSubmissions are populated by Form3 once the claim has been submitted.
+	ClaimSubmissions *Relationship `json:"claim_submissions,omitempty"`
+}
+
+func (c *Claim) resolveIncluded(included *Included) {
+	c.Relationships.resolveIncluded(included)
+}
+
+func (r *ClaimRelationships) resolveIncluded(included *Included) {
+	r.Payment.included = included
+	if r.ClaimSubmissions != nil {
+		r.ClaimSubmissions.included = included
+	}
+}
+
+// PaymentID returns the ID of the payment the claim was raised against.
+func (c *Claim) PaymentID() string {
+	return c.Relationships.Payment.ID()
+}
+
+// ClaimSubmission represents the submission of a claim to the payment scheme.
+type ClaimSubmission struct {
+	Attributes     ClaimSubmissionAttributes    `json:"attributes"`
+	ID             string                       `json:"id"`
+	OrganisationID string                       `json:"organisation_id"`
+	Type           string                       `json:"type"`
+	Version        int                          `json:"version"`
+	Relationships  ClaimSubmissionRelationships `json:"relationships"`
+}
+
+// ClaimSubmissionAttributes represents attributes of a ClaimSubmission
+type ClaimSubmissionAttributes struct {
+	Status             string `json:"status,omitempty"`
+	StatusReason       string `json:"status_reason,omitempty"`
+	SubmissionDatetime string `json:"submission_datetime,omitempty"`
+}
+
+// ClaimSubmissionRelationships represents the resources related to a ClaimSubmission
+type ClaimSubmissionRelationships struct {
+	Claim Relationship `json:"claim"`
+}
+
+// ClaimsService implements a service to raise and submit claims
+// See https://api-docs.form3.tech/api.html#transaction-claims
+type ClaimsService struct {
+	client     *Client
+	pagination Pagination
+}
+
+// NewClaimsService creates a new ClaimsService.
+func NewClaimsService(client *Client) *ClaimsService {
+	return &ClaimsService{
+		client:     client,
+		pagination: NewPagination(),
+	}
+}
+
+type fetchClaimAPIResponse struct {
+	Data     Claim             `json:"data"`
+	Included []json.RawMessage `json:"included,omitempty"`
+	Links    Links             `json:"links"`
+}
+
+type listClaimsAPIResponse struct {
+	Data  []Claim `json:"data"`
+	Links Links   `json:"links"`
+}
+
+type createClaimAPIPayload struct {
+	Data Claim `json:"data"`
+}
+
+type fetchClaimSubmissionAPIResponse struct {
+	Data  ClaimSubmission `json:"data"`
+	Links Links           `json:"links"`
+}
+
+type listClaimSubmissionsAPIResponse struct {
+	Data  []ClaimSubmission `json:"data"`
+	Links Links             `json:"links"`
+}
+
+type createClaimSubmissionAPIPayload struct {
+	Data ClaimSubmission `json:"data"`
+}
+
+// Fetch -> Get a single claim using the claim ID.
+//
+// GET /v1/transaction/claims/{claim_id}
+//
+// Pass WithInclude("payment") to have the payment returned alongside the claim.
+// Decode it from the claim's relationships: claim.Relationships.Payment.Decode(&payment).
+func (s *ClaimsService) Fetch(ctx context.Context, id string, options ...RequestOption) (*Claim, *http.Response, error) {
+	opt := MakeRequestOptions{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%s", claimsPath, id),
+	}
+	for _, option := range options {
+		option(&opt)
+	}
+
+	res, err := s.client.MakeRequest(ctx, opt)
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret fetchClaimAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	if err := resolveIncluded(ret.Included, &ret.Data); err != nil {
+		return nil, res, err
+	}
+
+	return &ret.Data, res, nil
+}
+
+// List -> List claims, paged using Number and Size.
+//
+// GET /v1/transaction/claims?page[number]={page_number}&page[size]={page_size}
+func (s *ClaimsService) List(ctx context.Context) ([]Claim, *http.Response, error) {
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "GET",
+		Path:   claimsPath,
+		Params: s.pagination.Params(),
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret listClaimsAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+	return ret.Data, res, nil
+}
+
+// Create -> Raise a claim against a payment. The claim is not sent to the scheme until it is submitted.
+//
+// POST /v1/transaction/claims
+//
+// The claim must have a supported reason and a relationship to the payment, e.g.
+//
+//	Relationships: form3.ClaimRelationships{Payment: form3.NewRelationship("payments", paymentID)}
+func (s *ClaimsService) Create(ctx context.Context, claim *Claim) (*Claim, *http.Response, error) {
+	if !claim.Attributes.Reason.Valid() {
+		return nil, nil, fmt.Errorf("unsupported claim reason %q", claim.Attributes.Reason)
+	}
+	if claim.PaymentID() == "" {
+		return nil, nil, fmt.Errorf("claim %s has no related payment", claim.ID)
+	}
+
+	data := &createClaimAPIPayload{Data: *claim}
+	if data.Data.OrganisationID == "" {
+		data.Data.OrganisationID = s.client.organisationID
+	}
+
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "POST",
+		Path:   claimsPath,
+		Body:   data,
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret fetchClaimAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	return &ret.Data, res, nil
+}
+
+// Submit -> Submit a claim to the payment scheme.
+//
+// POST /v1/transaction/claims/{claim_id}/submissions
+func (s *ClaimsService) Submit(ctx context.Context, claim *Claim) (*ClaimSubmission, *http.Response, error) {
+	data := &createClaimSubmissionAPIPayload{Data: ClaimSubmission{
+		ID:             newUUID(),
+		OrganisationID: claim.OrganisationID,
+		Type:           "claim_submissions",
+		Relationships: ClaimSubmissionRelationships{
+			Claim: NewRelationship("claims", claim.ID),
+		},
+	}}
+	if data.Data.OrganisationID == "" {
+		data.Data.OrganisationID = s.client.organisationID
+	}
+
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "POST",
+		Path:   fmt.Sprintf("%s/%s/submissions", claimsPath, claim.ID),
+		Body:   data,
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret fetchClaimSubmissionAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	return &ret.Data, res, nil
+}
+
+// FetchSubmission -> Get a single claim submission, including its status.
+//
+// GET /v1/transaction/claims/{claim_id}/submissions/{submission_id}
+func (s *ClaimsService) FetchSubmission(ctx context.Context, claimID, id string) (*ClaimSubmission, *http.Response, error) {
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%s/submissions/%s", claimsPath, claimID, id),
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret fetchClaimSubmissionAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	return &ret.Data, res, nil
+}
+
+// ListSubmissions -> List the submissions of a claim, paged using Number and Size.
+//
+// GET /v1/transaction/claims/{claim_id}/submissions?page[number]={page_number}&page[size]={page_size}
+func (s *ClaimsService) ListSubmissions(ctx context.Context, claimID string) ([]ClaimSubmission, *http.Response, error) {
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%s/submissions", claimsPath, claimID),
+		Params: s.pagination.Params(),
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret listClaimSubmissionsAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+	return ret.Data, res, nil
+}
+
+// Number -> page number requested. Defaults to 0.
+func (s *ClaimsService) Number(number int) ClaimsAPI {
+	s.pagination.Number = number
+	return s
+}
+
+// Size -> size is the max number of resources to return. Defaults to 10.
+func (s *ClaimsService) Size(size int) ClaimsAPI {
+	s.pagination.Size = size
+	return s
+}
@@ -0,0 +1,135 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func Test_FetchClaim_Success(t *testing.T) {
+	client, srv := testClient("/v1/transaction/claims/3f0c8a2e-5b1d-4c7e-9f2a-8d6b4e2c0a11", http.StatusOK, claimJSON)
+	defer srv.Close()
+
+	claim, res, err := client.Claims().Fetch(context.Background(), "3f0c8a2e-5b1d-4c7e-9f2a-8d6b4e2c0a11")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if http.StatusOK != res.StatusCode {
+		t.Error("Expected:", http.StatusOK, "Got:", res.StatusCode)
+	}
+
+	if claim.PaymentID() != "a8b6b5a2-0c1f-4d3e-9b7a-6f5e4d3c2b1a" {
+		t.Error("Expected: a8b6b5a2-0c1f-4d3e-9b7a-6f5e4d3c2b1a", "Got:", claim.PaymentID())
+	}
+
+	if claim.Attributes.Amount.Format(claim.Attributes.Currency) != "100.21" {
+		t.Error("Expected: 100.21", "Got:", claim.Attributes.Amount)
+	}
+}
+
+func Test_CreateClaim_InvalidReason_Failure(t *testing.T) {
+	client, srv := testClient("/v1/transaction/claims", http.StatusCreated, claimJSON)
+	defer srv.Close()
+
+	claim := &Claim{
+		Attributes:    ClaimAttributes{Reason: "because"},
+		Relationships: ClaimRelationships{Payment: NewRelationship("payments", "a8b6b5a2-0c1f-4d3e-9b7a-6f5e4d3c2b1a")},
+	}
+
+	if _, _, err := client.Claims().Create(context.Background(), claim); err == nil {
+		t.Error("Expected: error", "Got: nil")
+	}
+}
+
+func Test_SubmitClaim_Success(t *testing.T) {
+	var payload createClaimSubmissionAPIPayload
+	srv := serverMock("/v1/transaction/claims/3f0c8a2e-5b1d-4c7e-9f2a-8d6b4e2c0a11/submissions", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&payload)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(fetchClaimSubmissionAPIResponse{Data: payload.Data})
+	})
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	claim := &Claim{ID: "3f0c8a2e-5b1d-4c7e-9f2a-8d6b4e2c0a11", OrganisationID: "158f775d-4ecd-4861-b33d-30df9a29de78"}
+	submission, res, err := client.Claims().Submit(context.Background(), claim)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if http.StatusCreated != res.StatusCode {
+		t.Error("Expected:", http.StatusCreated, "Got:", res.StatusCode)
+	}
+
+	if submission.Relationships.Claim.ID() != claim.ID {
+		t.Error("Expected:", claim.ID, "Got:", submission.Relationships.Claim.ID())
+	}
+}
+
+func Test_ListClaimSubmissions_Success(t *testing.T) {
+	client, srv := testClient("/v1/transaction/claims/3f0c8a2e-5b1d-4c7e-9f2a-8d6b4e2c0a11/submissions", http.StatusOK, claimSubmissionsJSON)
+	defer srv.Close()
+
+	submissions, res, err := client.Claims().Number(0).Size(10).ListSubmissions(context.Background(), "3f0c8a2e-5b1d-4c7e-9f2a-8d6b4e2c0a11")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if http.StatusOK != res.StatusCode {
+		t.Error("Expected:", http.StatusOK, "Got:", res.StatusCode)
+	}
+
+	if len(submissions) != 2 {
+		t.Fatal("Expected:", 2, "Got:", len(submissions))
+	}
+
+	if submissions[1].Attributes.Status != "delivery_confirmed" {
+		t.Error("Expected: delivery_confirmed", "Got:", submissions[1].Attributes.Status)
+	}
+
+	if submissions[0].Relationships.Claim.ID() != "3f0c8a2e-5b1d-4c7e-9f2a-8d6b4e2c0a11" {
+		t.Error("Expected: 3f0c8a2e-5b1d-4c7e-9f2a-8d6b4e2c0a11", "Got:", submissions[0].Relationships.Claim.ID())
+	}
+}
+
+var claimJSON = `{
+	"data": {
+		"type": "claims",
+		"id": "3f0c8a2e-5b1d-4c7e-9f2a-8d6b4e2c0a11",
+		"version": 0,
+		"organisation_id": "158f775d-4ecd-4861-b33d-30df9a29de78",
+		"attributes": {
+			"reason": "non_receipt",
+			"amount": "100.21",
+			"currency": "GBP"
+		},
+		"relationships": {
+			"payment": {
+				"data": [{"type": "payments", "id": "a8b6b5a2-0c1f-4d3e-9b7a-6f5e4d3c2b1a"}]
+			}
+		}
+	}
+}`
+
+var claimSubmissionsJSON = `{
+	"data": [{
+		"type": "claim_submissions",
+		"id": "6a2d9e4b-7c1f-4e8a-b3d5-0f9e8d7c6b51",
+		"version": 0,
+		"organisation_id": "158f775d-4ecd-4861-b33d-30df9a29de78",
+		"attributes": {"status": "delivery_failed", "status_reason": "scheme timeout"},
+		"relationships": {"claim": {"data": [{"type": "claims", "id": "3f0c8a2e-5b1d-4c7e-9f2a-8d6b4e2c0a11"}]}}
+	}, {
+		"type": "claim_submissions",
+		"id": "6a2d9e4b-7c1f-4e8a-b3d5-0f9e8d7c6b52",
+		"version": 0,
+		"organisation_id": "158f775d-4ecd-4861-b33d-30df9a29de78",
+		"attributes": {"status": "delivery_confirmed", "submission_datetime": "2021-03-04T10:11:12.000Z"},
+		"relationships": {"claim": {"data": [{"type": "claims", "id": "3f0c8a2e-5b1d-4c7e-9f2a-8d6b4e2c0a11"}]}}
+	}],
+	"links": {
+		"self": "/v1/transaction/claims/3f0c8a2e-5b1d-4c7e-9f2a-8d6b4e2c0a11/submissions?page[number]=0&page[size]=10"
+	}
+}`
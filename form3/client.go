@@ -6,11 +6,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
+	"strings"
+	"time"
 )
 
 const (
@@ -20,6 +24,8 @@ const (
 	defaultHost   string = "localhost:8080"
 )
 
+var apiVersionPattern = regexp.MustCompile(`^v[0-9]+$`)
+
 // Client is a Form3 client. Create one by calling NewClient.
 type Client struct {
 	httpClient *http.Client
@@ -27,6 +33,24 @@ type Client struct {
 	errorLog   *log.Logger // error log for critical messages
 	scheme     string      // http or https
 	host       string      // host
+	pathPrefix string      // prefix of every path, e.g. /form3 behind a gateway
+
+	organisationID string            // default organisation for created resources
+	routingCache   *routingCache     // routes looked up by RoutingService
+	strictDecoding bool              // reject responses that do not match the models, see SetStrictDecoding
+	authToken      string            // bearer token sent with every request
+	signer         *requestSigner    // signs every request, see SetSigningKey
+	retryPolicy    RetryPolicy       // retries of transient failures, see SetRetryPolicy
+	apiVersions    map[string]string // API versions by path, see SetAPIVersion
+	timeout        time.Duration     // limit on waiting for the response to each attempt, see SetTimeout
+
+	// wrapped around the transport once options are applied, see WrapTransport
+	wrappers []func(http.RoundTripper) http.RoundTripper
+
+	// the option that first customised the transport, which SetHTTPClient would discard
+	transportSetBy string
+	// the transport came from SetHTTPClient or SetTransport, so the transport options adjust a clone of it
+	transportShared bool
 }
 
 // NewClient creates a new client to work with the Form3 API.
@@ -34,9 +58,12 @@ func NewClient(options ...ClientOptionFunc) (*Client, error) {
 	c := &Client{
 		scheme:     defaultScheme,
 		host:       defaultHost,
-		httpClient: &http.Client{},
+		httpClient: &http.Client{Transport: NewTransport()},
 		infoLog:    log.New(os.Stderr, "[form3_info]", log.LstdFlags),
 		errorLog:   log.New(os.Stderr, "[form3_error]", log.LstdFlags),
+
+		routingCache: newRoutingCache(defaultRoutingCacheTTL),
+		retryPolicy:  defaultRetryPolicy,
 	}
 
 	// Apply passed options (if any), overriding defaults
@@ -45,6 +72,7 @@ func NewClient(options ...ClientOptionFunc) (*Client, error) {
 			return nil, err
 		}
 	}
+	c.wrapTransport()
 
 	return c, nil
 }
@@ -58,8 +86,12 @@ type MakeRequestOptions struct {
 	Path   string
 	Params url.Values
 	Body   interface{}
+	Header http.Header // added to (or replacing) the default headers
 }
 
+// RequestOption is a function that adjusts a single request made by a service, e.g. WithInclude.
+type RequestOption func(*MakeRequestOptions)
+
 // SetScheme sets the HTTP scheme (http by default)
 func SetScheme(scheme string) ClientOptionFunc {
 	return func(c *Client) error {
@@ -76,13 +108,177 @@ func SetHost(host string) ClientOptionFunc {
 	}
 }
 
+// SetBaseURL sets the scheme, host and path prefix of the API (http://localhost:8080 by default).
+// The prefix allows reaching Form3 through a gateway or proxy, e.g. https://egress.internal/form3/
+// sends accounts requests to https://egress.internal/form3/v1/organisation/accounts.
+func SetBaseURL(u *url.URL) ClientOptionFunc {
+	return func(c *Client) error {
+		if u == nil {
+			return errors.New("base URL is nil")
+		}
+		if err := checkBaseURL(u); err != nil {
+			return fmt.Errorf("base URL %q: %w", u, err)
+		}
+
+		c.scheme = u.Scheme
+		c.host = u.Host
+		c.pathPrefix = strings.TrimSuffix(u.Path, "/")
+		return nil
+	}
+}
+
+func checkBaseURL(u *url.URL) error {
+	switch {
+	case u.Scheme != "http" && u.Scheme != "https":
+		return errors.New("scheme must be http or https")
+	case u.Host == "":
+		return errors.New("host is missing")
+	case u.User != nil:
+		return errors.New("credentials are not supported, see SetAuthToken")
+	case u.RawQuery != "" || u.Fragment != "":
+		return errors.New("must not have a query or fragment")
+	}
+	return nil
+}
+
+// SetAPIVersion sets the API version of the endpoints under path (v1 by default), for endpoints that moved to a
+// new version before the others, e.g. SetAPIVersion("/organisation/accounts", "v2").
+// When several paths match a request, the longest one wins.
+func SetAPIVersion(path, version string) ClientOptionFunc {
+	return func(c *Client) error {
+		if err := checkAPIVersion(path, version); err != nil {
+			return err
+		}
+
+		if c.apiVersions == nil {
+			c.apiVersions = map[string]string{}
+		}
+		c.apiVersions[strings.TrimSuffix(path, "/")] = version
+		return nil
+	}
+}
+
+func checkAPIVersion(path, version string) error {
+	if !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("API version path %q must start with /", path)
+	}
+	if !apiVersionPattern.MatchString(version) {
+		return fmt.Errorf("API version %q must look like v2", version)
+	}
+	return nil
+}
+
+// SetTransport sets the http.RoundTripper used to make requests (NewTransport by default),
+// e.g. to record and replay them in tests.
+func SetTransport(transport http.RoundTripper) ClientOptionFunc {
+	return func(c *Client) error {
+		c.httpClient.Transport = transport
+		c.transportShared = true
+		c.customiseTransport("SetTransport")
+		return nil
+	}
+}
+
+// WrapTransport wraps the transport with wrap, e.g. to add instrumentation or inject faults around it.
+// Wrappers are applied once every option has been, around the final transport (NewTransport by default),
+// so they are kept by SetTransport and SetHTTPClient, and transport options still apply, whatever their order.
+// The first wrapper given is the innermost.
+func WrapTransport(wrap func(http.RoundTripper) http.RoundTripper) ClientOptionFunc {
+	return func(c *Client) error {
+		if wrap == nil {
+			return errors.New("transport wrapper is nil")
+		}
+		c.wrappers = append(c.wrappers, wrap)
+		return nil
+	}
+}
+
+// wrapTransport applies the wrappers set with WrapTransport. The http.Client is the client's own, see SetHTTPClient.
+func (c *Client) wrapTransport() {
+	if len(c.wrappers) == 0 {
+		return
+	}
+
+	next := c.httpClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	for _, wrap := range c.wrappers {
+		next = wrap(next)
+	}
+	c.httpClient.Transport = next
+}
+
+// SetInfoLog sets the logger for non-critical messages (stderr by default, nil disables it)
+func SetInfoLog(logger *log.Logger) ClientOptionFunc {
+	return func(c *Client) error {
+		c.infoLog = logger
+		return nil
+	}
+}
+
+// SetErrorLog sets the logger for critical messages (stderr by default, nil disables it)
+func SetErrorLog(logger *log.Logger) ClientOptionFunc {
+	return func(c *Client) error {
+		c.errorLog = logger
+		return nil
+	}
+}
+
+// SetAuthToken sets the bearer token sent in the Authorization header of every request.
+func SetAuthToken(token string) ClientOptionFunc {
+	return func(c *Client) error {
+		c.authToken = token
+		return nil
+	}
+}
+
+// SetTimeout sets the time limit of each attempt at a request until the response headers arrive
+// (none by default, 0 removes it). The transport of NewTransport also gives up on the response headers after 30s,
+// whatever the timeout. Reading the body is not limited, so that Reports().Download can stream large reports;
+// use the request context to bound a whole call.
+func SetTimeout(timeout time.Duration) ClientOptionFunc {
+	return func(c *Client) error {
+		if timeout < 0 {
+			return fmt.Errorf("timeout %s is negative", timeout)
+		}
+		c.timeout = timeout
+		return nil
+	}
+}
+
+// SetOrganisationID sets the default organisation. It is used when creating resources with no OrganisationID.
+func SetOrganisationID(organisationID string) ClientOptionFunc {
+	return func(c *Client) error {
+		c.organisationID = organisationID
+		return nil
+	}
+}
+
+// SetRoutingCacheTTL sets how long routing lookups are cached (10 minutes by default, 0 disables caching)
+func SetRoutingCacheTTL(ttl time.Duration) ClientOptionFunc {
+	return func(c *Client) error {
+		c.routingCache = newRoutingCache(ttl)
+		return nil
+	}
+}
+
+// SetStrictDecoding makes Decode fail with a *StrictDecodingError when a response has fields the models do not know,
+// or lacks required ones (off by default). Use it in contract tests to catch API changes early.
+func SetStrictDecoding(strict bool) ClientOptionFunc {
+	return func(c *Client) error {
+		c.strictDecoding = strict
+		return nil
+	}
+}
+
 // MakeRequest makes a HTTP request to the Form3 API.
 // It returns a *http.Response and an error (on failure.
 func (c *Client) MakeRequest(ctx context.Context, opt MakeRequestOptions) (*http.Response, error) {
 	u := url.URL{
 		Scheme:   c.scheme,
 		Host:     c.host,
-		Path:     fmt.Sprintf("%s%s", apiVersion, opt.Path),
+		Path:     fmt.Sprintf("%s/%s%s", c.pathPrefix, c.apiVersion(opt.Path), opt.Path),
 		RawQuery: opt.Params.Encode(),
 	}
 
@@ -91,11 +287,28 @@ func (c *Client) MakeRequest(ctx context.Context, opt MakeRequestOptions) (*http
 		payload, _ = json.Marshal(opt.Body)
 	}
 
-	request, _ := http.NewRequest(opt.Method, u.String(), bytes.NewBuffer(payload))
-	request.Header.Add("Accept", contentType)
-	request.Header.Add("Content-Type", contentType)
+	var response *http.Response
+	var err error
+	for attempt := 1; ; attempt++ {
+		response, err = c.do(ctx, opt, u.String(), payload)
 
-	response, err := c.httpClient.Do(request)
+		backoff, retry := c.retryPolicy.retry(attempt, opt.Method, response, err)
+		if !retry {
+			break
+		}
+		if err != nil {
+			c.infof("%s -> %s -> %s, retrying in %s", opt.Method, u.String(), err.Error(), backoff)
+		} else {
+			c.infof("%s -> %s -> %s, retrying in %s", opt.Method, u.String(), response.Status, backoff)
+			response.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+	}
 	if err != nil {
 		return response, err
 	}
@@ -110,6 +323,79 @@ func (c *Client) MakeRequest(ctx context.Context, opt MakeRequestOptions) (*http
 	return response, nil
 }
 
+// apiVersion returns the API version of path, see SetAPIVersion.
+func (c *Client) apiVersion(path string) string {
+	version, longest := apiVersion, -1
+	for prefix, v := range c.apiVersions {
+		if (path == prefix || strings.HasPrefix(path, prefix+"/")) && len(prefix) > longest {
+			version, longest = v, len(prefix)
+		}
+	}
+	return version
+}
+
+// do makes a single attempt at a request.
+func (c *Client) do(ctx context.Context, opt MakeRequestOptions, target string, payload []byte) (*http.Response, error) {
+	request, _ := http.NewRequestWithContext(ctx, opt.Method, target, bytes.NewBuffer(payload))
+	request.Header.Add("Accept", contentType)
+	request.Header.Add("Content-Type", contentType)
+	if c.authToken != "" {
+		request.Header.Add("Authorization", "Bearer "+c.authToken)
+	}
+	for key, values := range opt.Header {
+		request.Header.Del(key)
+		for _, value := range values {
+			request.Header.Add(key, value)
+		}
+	}
+
+	if c.signer != nil {
+		if err := c.signer.sign(request, payload); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.timeout == 0 {
+		return c.httpClient.Do(request)
+	}
+	return c.doWithTimeout(request)
+}
+
+// doWithTimeout sends the request, cancelling it if the response headers do not arrive within the timeout.
+// Once they have, the request is only cancelled when the body is closed.
+func (c *Client) doWithTimeout(request *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithCancel(request.Context())
+	timer := time.AfterFunc(c.timeout, cancel)
+
+	response, err := c.httpClient.Do(request.WithContext(ctx))
+	if !timer.Stop() && request.Context().Err() == nil {
+		if response != nil {
+			response.Body.Close()
+		}
+		cancel()
+		return nil, fmt.Errorf("%s %s: no response within %s", request.Method, request.URL, c.timeout)
+	}
+	if err != nil {
+		cancel()
+		return response, err
+	}
+
+	response.Body = &cancelOnClose{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
+
+// cancelOnClose is a response body releasing the context of its request when closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
 func checkResponse(res *http.Response) error {
 	// 200-299 are valid status codes
 	if res.StatusCode >= 200 && res.StatusCode <= 299 {
@@ -119,16 +405,33 @@ func checkResponse(res *http.Response) error {
 	return errors.New(res.Status)
 }
 
-// Decode decodes with json.Unmarshal from the Go standard library.
+// Decode decodes with json.Unmarshal from the Go standard library, or strictly if SetStrictDecoding is on.
+// The body is left readable afterwards, see RawJSON.
 func (c *Client) Decode(response *http.Response, v interface{}) error {
-	body, err := ioutil.ReadAll(response.Body)
+	body, err := RawJSON(response)
 	if err != nil {
 		return err
 	}
 
+	if c.strictDecoding {
+		return decodeStrict(body, v)
+	}
 	return json.Unmarshal(body, v)
 }
 
+// RawJSON returns the raw JSON body of a response returned by a service, e.g. to inspect members this client does not model.
+// It can be called any number of times, before or after the service decoded the response.
+func RawJSON(response *http.Response) (json.RawMessage, error) {
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	response.Body.Close()
+
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
 // errorf logs to the error log.
 func (c *Client) errorf(format string, args ...interface{}) {
 	if c.errorLog != nil {
@@ -144,6 +447,56 @@ func (c *Client) infof(format string, args ...interface{}) {
 }
 
 // Accounts returns a service to handle accounts
-func (c *Client) Accounts() *AccountsService {
+func (c *Client) Accounts() AccountsAPI {
 	return NewAccountsService(c)
 }
+
+// Subscriptions returns a service to handle notification subscriptions
+func (c *Client) Subscriptions() SubscriptionsAPI {
+	return NewSubscriptionsService(c)
+}
+
+// Organisations returns a service to handle organisations and organisation units
+func (c *Client) Organisations() OrganisationsAPI {
+	return NewOrganisationsService(c)
+}
+
+// BankIDs returns a service to handle registered bank IDs
+func (c *Client) BankIDs() BankIDsAPI {
+	return NewBankIDsService(c)
+}
+
+// Bics returns a service to handle registered BICs
+func (c *Client) Bics() BicsAPI {
+	return NewBicsService(c)
+}
+
+// Security returns a service to handle users, roles, access control entries and credentials
+func (c *Client) Security() SecurityAPI {
+	return NewSecurityService(c)
+}
+
+// Reports returns a service to generate and download reports
+func (c *Client) Reports() ReportsAPI {
+	return NewReportsService(c)
+}
+
+// Audit returns a service to query audit entries
+func (c *Client) Audit() AuditAPI {
+	return NewAuditService(c)
+}
+
+// Limits returns a service to query scheme limits
+func (c *Client) Limits() LimitsAPI {
+	return NewLimitsService(c)
+}
+
+// Claims returns a service to raise and submit claims
+func (c *Client) Claims() ClaimsAPI {
+	return NewClaimsService(c)
+}
+
+// Routing returns a service to look up account routing and reachability
+func (c *Client) Routing() RoutingAPI {
+	return NewRoutingService(c)
+}
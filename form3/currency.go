@@ -0,0 +1,64 @@
+package form3
+
+// Currency is an ISO 4217 currency code, e.g. "GBP".
+type Currency string
+
+// Currencies commonly used with Form3.
+const (
+	AUD Currency = "AUD"
+	BHD Currency = "BHD"
+	CAD Currency = "CAD"
+	CHF Currency = "CHF"
+	CZK Currency = "CZK"
+	DKK Currency = "DKK"
+	EUR Currency = "EUR"
+	GBP Currency = "GBP"
+	HKD Currency = "HKD"
+	JOD Currency = "JOD"
+	JPY Currency = "JPY"
+	KWD Currency = "KWD"
+	NOK Currency = "NOK"
+	PLN Currency = "PLN"
+	SEK Currency = "SEK"
+	USD Currency = "USD"
+)
+
+// minorUnits is the number of digits after the decimal point for each known currency (ISO 4217 exponent).
+var minorUnits = map[Currency]int32{
+	AUD: 2,
+	BHD: 3,
+	CAD: 2,
+	CHF: 2,
+	CZK: 2,
+	DKK: 2,
+	EUR: 2,
+	GBP: 2,
+	HKD: 2,
+	JOD: 3,
+	JPY: 0,
+	KWD: 3,
+	NOK: 2,
+	PLN: 2,
+	SEK: 2,
+	USD: 2,
+}
+
+// Known reports whether c is one of the currencies defined by this package.
+func (c Currency) Known() bool {
+	_, ok := minorUnits[c]
+	return ok
+}
+
+// MinorUnits returns the number of digits after the decimal point used by the currency: 0 for JPY, 2 for GBP, 3 for BHD.
+// Unknown currencies are assumed to use 2.
+func (c Currency) MinorUnits() int {
+	if units, ok := minorUnits[c]; ok {
+		return int(units)
+	}
+	return 2
+}
+
+// String returns the currency code.
+func (c Currency) String() string {
+	return string(c)
+}
@@ -0,0 +1,64 @@
+package form3
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// unmarshalExtra decodes data into v, a pointer to a struct, and returns the members of data
+// that do not match any of its fields, or nil if there are none.
+//
+// Resources use it to keep attributes added to the API after this client was written, so that a
+// Fetch -> modify -> Update round trip does not erase them:
+//
+//	func (a *Account) UnmarshalJSON(b []byte) error {
+//		type account Account
+//		extra, err := unmarshalExtra(b, (*account)(a))
+//		...
+func unmarshalExtra(data []byte, v interface{}) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	for _, name := range jsonFieldNames(reflect.TypeOf(v).Elem()) {
+		delete(members, name)
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+	return members, nil
+}
+
+// marshalExtra encodes v and adds the members of extra that v does not already encode.
+func marshalExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return b, err
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(b, &members); err != nil {
+		return nil, err
+	}
+	for name, value := range extra {
+		if _, ok := members[name]; !ok {
+			members[name] = value
+		}
+	}
+	return json.Marshal(members)
+}
+
+// jsonFieldNames returns the JSON member names of the fields of struct type t.
+func jsonFieldNames(t reflect.Type) []string {
+	fields := jsonFields(t)
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.name
+	}
+	return names
+}
@@ -0,0 +1,111 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_UnmarshalAccount_Extra_Success(t *testing.T) {
+	var account Account
+	if err := json.Unmarshal([]byte(accountWithExtraJSON), &account); err != nil {
+		t.Fatal(err)
+	}
+
+	if account.Attributes.Country != "GB" {
+		t.Error("Expected: GB", "Got:", account.Attributes.Country)
+	}
+
+	if string(account.Extra["created_on"]) != `"2021-03-01T10:00:00.000Z"` {
+		t.Error("Expected: created_on", "Got:", account.Extra)
+	}
+
+	if string(account.Attributes.Extra["status"]) != `"confirmed"` {
+		t.Error("Expected: status", "Got:", account.Attributes.Extra)
+	}
+
+	if _, ok := account.Attributes.Extra["country"]; ok {
+		t.Error("Expected known attributes to be left out of Extra, Got:", account.Attributes.Extra)
+	}
+}
+
+func Test_UnmarshalAccount_NoExtra_Success(t *testing.T) {
+	var account Account
+	if err := json.Unmarshal([]byte(`{"id": "a", "attributes": {"country": "GB"}}`), &account); err != nil {
+		t.Fatal(err)
+	}
+
+	if account.Extra != nil || account.Attributes.Extra != nil {
+		t.Error("Expected: nil Extra", "Got:", account.Extra, account.Attributes.Extra)
+	}
+}
+
+func Test_UpdateAccount_PreservesExtra_Success(t *testing.T) {
+	var payload map[string]map[string]json.RawMessage
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			json.NewDecoder(r.Body).Decode(&payload)
+		}
+		w.Write([]byte(`{"data": ` + accountWithExtraJSON + `}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	account, _, err := client.Accounts().Fetch(context.Background(), "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account.Attributes.Country = "FR"
+	if _, _, err := client.Accounts().Update(context.Background(), account); err != nil {
+		t.Fatal(err)
+	}
+
+	if string(payload["data"]["created_on"]) != `"2021-03-01T10:00:00.000Z"` {
+		t.Error("Expected: created_on to be sent", "Got:", string(payload["data"]["created_on"]))
+	}
+
+	attributes := string(payload["data"]["attributes"])
+	if !strings.Contains(attributes, `"status":"confirmed"`) || !strings.Contains(attributes, `"country":"FR"`) {
+		t.Error("Expected: status and the updated country to be sent", "Got:", attributes)
+	}
+}
+
+func Test_RawJSON_Success(t *testing.T) {
+	client, srv := testClient("/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", http.StatusOK, accountJSON)
+	defer srv.Close()
+
+	_, res, err := client.Accounts().Fetch(context.Background(), "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		raw, err := RawJSON(res)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(raw) != accountJSON {
+			t.Error("Expected:", accountJSON, "Got:", string(raw))
+		}
+	}
+}
+
+var accountWithExtraJSON = `{
+	"type": "accounts",
+	"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+	"organisation_id": "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+	"version": 0,
+	"created_on": "2021-03-01T10:00:00.000Z",
+	"attributes": {
+		"country": "GB",
+		"bank_id": "400300",
+		"bank_id_code": "GBDSC",
+		"status": "confirmed"
+	}
+}`
@@ -0,0 +1,188 @@
+// Package form3config configures clients from the environment and from YAML config files with a profile per environment,
+// keeping the YAML dependency out of the form3 package:
+//
+//	client, err := form3config.NewClientFromEnv(form3.SetInfoLog(nil))
+package form3config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+)
+
+// Environment variables read by NewClientFromEnv, on top of those read by form3.ProfileFromEnv (form3.EnvBaseURL, ...).
+const (
+	EnvConfig  string = "FORM3_CONFIG"  // config file, ~/.form3/config.yaml by default
+	EnvProfile string = "FORM3_PROFILE" // profile to use, overriding the file's profile setting
+)
+
+// Config is a config file with named profiles, e.g.
+//
+//	profile: sandbox                # used unless FORM3_PROFILE is set
+//	timeout: 30s                    # top level settings apply to every profile
+//	profiles:
+//	  local:
+//	    base_url: http://localhost:8080
+//	  sandbox:
+//	    base_url: https://api.staging-form3.tech
+//	    organisation_id: eb0bd6f5-c3f5-44b2-b677-acd23cdde73c
+//	    signing_key_id: 75a8ba12-fff2-4a52-ad8a-e8b34c5ccec8
+//	    signing_key_path: sandbox.pem
+//	    retry: {max_attempts: 3, min_backoff: 200ms, max_backoff: 5s}
+//	    api_versions: {/organisation/accounts: v2}
+type Config struct {
+	Defaults form3.Profile            `yaml:",inline"`
+	Current  string                   `yaml:"profile"`
+	Profiles map[string]form3.Profile `yaml:"profiles"`
+}
+
+// DefaultConfigPath returns ~/.form3/config.yaml, or "" when there is no home directory.
+func DefaultConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".form3", "config.yaml")
+}
+
+// LoadConfig reads the config file at path. Unknown settings are errors, to catch typos.
+func LoadConfig(path string) (*Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	defer f.Close()
+
+	var config Config
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("config %s: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	resolvePaths(&config.Defaults, dir)
+	for name, profile := range config.Profiles {
+		resolvePaths(&profile, dir)
+		config.Profiles[name] = profile
+	}
+	return &config, nil
+}
+
+// Resolve returns the named profile, or the config's current one when name is empty, on top of the top level settings.
+// With neither, it returns the top level settings alone.
+func (c *Config) Resolve(name string) (form3.Profile, error) {
+	if name == "" {
+		name = c.Current
+	}
+	profile := c.Defaults
+	if name == "" {
+		return profile, nil
+	}
+
+	named, ok := c.Profiles[name]
+	if !ok {
+		return form3.Profile{}, fmt.Errorf("profile %q not found, the config has %s", name, profileNames(c.Profiles))
+	}
+	profile.Merge(named)
+	profile.Name = name
+	return profile, nil
+}
+
+// ProfileFromEnv returns the profile NewClientFromEnv uses, looking up environment variables with getenv (e.g. os.Getenv).
+// Settings are taken, from lowest to highest precedence, from:
+//
+//  1. the client defaults (http://localhost:8080, no auth, no retries, and no timeout but the 30s
+//     NewTransport waits for response headers)
+//  2. the top level settings of the config file (FORM3_CONFIG, or ~/.form3/config.yaml if it exists)
+//  3. the profile named by FORM3_PROFILE, or by the config file's profile setting
+//  4. the FORM3_BASE_URL, FORM3_ORGANISATION_ID, ... environment variables, see form3.ProfileFromEnv
+func ProfileFromEnv(getenv func(string) string) (form3.Profile, error) {
+	var profile form3.Profile
+
+	path, required := getenv(EnvConfig), true
+	if path == "" {
+		path, required = DefaultConfigPath(), false
+	}
+
+	_, statErr := os.Stat(path)
+	switch {
+	case path != "" && (required || statErr == nil):
+		config, err := LoadConfig(path)
+		if err != nil {
+			return form3.Profile{}, err
+		}
+		if profile, err = config.Resolve(getenv(EnvProfile)); err != nil {
+			return form3.Profile{}, fmt.Errorf("config %s: %w", path, err)
+		}
+	case getenv(EnvProfile) != "":
+		return form3.Profile{}, fmt.Errorf("%s is %q but there is no config file at %s", EnvProfile, getenv(EnvProfile), path)
+	}
+
+	env, err := form3.ProfileFromEnv(getenv)
+	if err != nil {
+		return form3.Profile{}, err
+	}
+	profile.Merge(env)
+
+	return profile, nil
+}
+
+// NewClientFromEnv creates a new client configured by the environment and config file, see ProfileFromEnv.
+// form3.NewClientFromEnv reads the environment alone.
+// options are applied last, so they override both. Misconfigurations are reported here rather than on the first request.
+func NewClientFromEnv(options ...form3.ClientOptionFunc) (*form3.Client, error) {
+	profile, err := ProfileFromEnv(os.Getenv)
+	if err != nil {
+		return nil, err
+	}
+
+	profileOptions, err := profile.Options()
+	if err != nil {
+		return nil, err
+	}
+	return form3.NewClient(append(profileOptions, options...)...)
+}
+
+// resolvePaths makes the file paths of the profile, from a config file in dir, absolute.
+func resolvePaths(p *form3.Profile, dir string) {
+	p.SigningKeyPath = resolvePath(dir, p.SigningKeyPath)
+	p.CABundle = resolvePath(dir, p.CABundle)
+	p.ClientCert = resolvePath(dir, p.ClientCert)
+	p.ClientKey = resolvePath(dir, p.ClientKey)
+}
+
+// resolvePath makes path, from a config file in dir, absolute.
+func resolvePath(dir, path string) string {
+	switch {
+	case path == "" || filepath.IsAbs(path):
+		return path
+	case path == "~" || strings.HasPrefix(path, "~/"):
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+func profileNames(profiles map[string]form3.Profile) string {
+	if len(profiles) == 0 {
+		return "no profiles"
+	}
+
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, fmt.Sprintf("%q", name))
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
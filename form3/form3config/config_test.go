@@ -0,0 +1,101 @@
+package form3config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+)
+
+const testConfig = `
+profile: sandbox
+timeout: 30s
+organisation_id: eb0bd6f5-c3f5-44b2-b677-acd23cdde73c
+profiles:
+  local:
+    base_url: http://localhost:8080
+  sandbox:
+    base_url: https://api.staging-form3.tech
+    token: sandbox-token
+    signing_key_path: keys/sandbox.pem
+    retry:
+      max_attempts: 3
+      min_backoff: 200ms
+      max_backoff: 5s
+`
+
+func Test_ProfileFromEnv_Success(t *testing.T) {
+	path := writeConfig(t, testConfig)
+
+	environment := map[string]string{EnvConfig: path}
+	profile, err := ProfileFromEnv(func(key string) string { return environment[key] })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := form3.Profile{
+		BaseURL:        "https://api.staging-form3.tech",
+		OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+		Token:          "sandbox-token",
+		SigningKeyPath: filepath.Join(filepath.Dir(path), "keys", "sandbox.pem"),
+		Timeout:        30 * time.Second,
+		Retry:          &form3.RetryPolicy{MaxAttempts: 3, MinBackoff: 200 * time.Millisecond, MaxBackoff: 5 * time.Second},
+		Name:           "sandbox",
+	}
+	if profile.BaseURL != expected.BaseURL || profile.OrganisationID != expected.OrganisationID || profile.Token != expected.Token ||
+		profile.SigningKeyPath != expected.SigningKeyPath || profile.Timeout != expected.Timeout || *profile.Retry != *expected.Retry ||
+		profile.Name != expected.Name {
+		t.Error("Expected:", expected, "Got:", profile)
+	}
+
+	// FORM3_PROFILE picks another profile, and the other variables override it
+	environment[EnvProfile] = "local"
+	environment[form3.EnvBaseURL] = "http://localhost:9090"
+	environment[form3.EnvTimeout] = "5s"
+	profile, err = ProfileFromEnv(func(key string) string { return environment[key] })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.BaseURL != "http://localhost:9090" || profile.Timeout != 5*time.Second || profile.Token != "" || profile.Retry != nil {
+		t.Error("Expected: local profile with overrides", "Got:", profile)
+	}
+}
+
+func Test_ProfileFromEnv_Failure(t *testing.T) {
+	path := writeConfig(t, testConfig)
+
+	tests := map[string]map[string]string{
+		"profile \"production\" not found": {EnvConfig: path, EnvProfile: "production"},
+		"no such file":                     {EnvConfig: filepath.Join(t.TempDir(), "missing.yaml")},
+		"is not a duration":                {EnvConfig: path, form3.EnvTimeout: "30"},
+		"field tiemout not found":          {EnvConfig: writeConfig(t, "tiemout: 30s\n")},
+	}
+	for expected, environment := range tests {
+		_, err := ProfileFromEnv(func(key string) string { return environment[key] })
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Error("Expected:", expected, "Got:", err)
+		}
+	}
+}
+
+func Test_NewClientFromEnv_Failure(t *testing.T) {
+	t.Setenv(EnvConfig, writeConfig(t, "profiles:\n  staging:\n    base_url: staging\n"))
+	t.Setenv(EnvProfile, "staging")
+
+	_, err := NewClientFromEnv()
+	if err == nil || !strings.Contains(err.Error(), "profile \"staging\": base_url") {
+		t.Error("Expected: an error naming the profile", "Got:", err)
+	}
+}
+
+// writeConfig writes a config file and returns its path.
+func writeConfig(t *testing.T, config string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
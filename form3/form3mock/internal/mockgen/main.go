@@ -0,0 +1,216 @@
+// Command mockgen generates the mocks of form3mock from the interfaces declared in a source file of package form3.
+//
+//	go run ./internal/mockgen -source ../interfaces.go -output mocks_gen.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const form3Import string = "github.com/form3tech-oss/interview-accountapi/form3"
+
+type mock struct {
+	Name    string
+	Methods []method
+}
+
+type method struct {
+	Name    string
+	Params  string   // parameter list of the signature
+	Args    string   // arguments recorded for the call, without the context
+	Results []string // result types
+	Chain   bool     // returns the interface itself, e.g. Number
+}
+
+func main() {
+	source := flag.String("source", "", "Go file declaring the interfaces to mock")
+	output := flag.String("output", "", "file to write the mocks to")
+	flag.Parse()
+
+	src, err := generate(*source)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate(source string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, source, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		imports:    map[string]string{},
+		used:       map[string]bool{"testing": true},
+		interfaces: map[string]bool{},
+	}
+	for _, spec := range file.Imports {
+		p, _ := strconv.Unquote(spec.Path.Value)
+		g.imports[path.Base(p)] = p
+	}
+
+	var specs []*ast.TypeSpec
+	ast.Inspect(file, func(n ast.Node) bool {
+		if spec, ok := n.(*ast.TypeSpec); ok {
+			if _, ok := spec.Type.(*ast.InterfaceType); ok {
+				specs = append(specs, spec)
+				g.interfaces[spec.Name.Name] = true
+			}
+		}
+		return true
+	})
+
+	var mocks []mock
+	for _, spec := range specs {
+		m, err := g.mock(spec)
+		if err != nil {
+			return nil, err
+		}
+		mocks = append(mocks, m)
+	}
+
+	var imports []string
+	for name := range g.used {
+		if p, ok := g.imports[name]; ok {
+			imports = append(imports, p)
+		} else {
+			imports = append(imports, name)
+		}
+	}
+	sort.Strings(imports)
+
+	var buf bytes.Buffer
+	data := map[string]interface{}{"Imports": imports, "Form3Import": form3Import, "Mocks": mocks}
+	if err := mocksTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+type generator struct {
+	imports    map[string]string // package name -> import path, from the source file
+	used       map[string]bool   // package names used by the mocks
+	interfaces map[string]bool   // interfaces declared in the source file
+}
+
+func (g *generator) mock(spec *ast.TypeSpec) (mock, error) {
+	m := mock{Name: spec.Name.Name}
+
+	for _, field := range spec.Type.(*ast.InterfaceType).Methods.List {
+		fn, ok := field.Type.(*ast.FuncType)
+		if !ok {
+			return m, fmt.Errorf("%s embeds %s: embedded interfaces are not supported", m.Name, g.typeString(field.Type))
+		}
+
+		meth := method{Name: field.Names[0].Name}
+
+		var params, args []string
+		for _, param := range fn.Params.List {
+			typ := g.typeString(param.Type)
+			for _, name := range param.Names {
+				params = append(params, name.Name+" "+typ)
+				if typ != "context.Context" {
+					args = append(args, name.Name)
+				}
+			}
+		}
+		meth.Params = strings.Join(params, ", ")
+		meth.Args = strings.Join(args, ", ")
+
+		if fn.Results != nil {
+			for _, result := range fn.Results.List {
+				meth.Results = append(meth.Results, g.typeString(result.Type))
+			}
+			if ident, ok := fn.Results.List[0].Type.(*ast.Ident); ok && len(fn.Results.List) == 1 && ident.Name == m.Name {
+				meth.Chain = true
+			}
+		}
+
+		m.Methods = append(m.Methods, meth)
+	}
+	return m, nil
+}
+
+// typeString returns the type as written in package form3mock, qualifying the types of package form3.
+func (g *generator) typeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if ast.IsExported(t.Name) {
+			return "form3." + t.Name
+		}
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + g.typeString(t.X)
+	case *ast.ArrayType:
+		return "[]" + g.typeString(t.Elt)
+	case *ast.Ellipsis:
+		return "..." + g.typeString(t.Elt)
+	case *ast.MapType:
+		return "map[" + g.typeString(t.Key) + "]" + g.typeString(t.Value)
+	case *ast.SelectorExpr:
+		pkg := t.X.(*ast.Ident).Name
+		g.used[pkg] = true
+		return pkg + "." + t.Sel.Name
+	case *ast.InterfaceType:
+		return "interface{}"
+	}
+	panic(fmt.Sprintf("unsupported type %T", expr))
+}
+
+var mocksTemplate = template.Must(template.New("mocks").Funcs(template.FuncMap{
+	"join": strings.Join,
+}).Parse(`// Code generated by mockgen from form3/interfaces.go. DO NOT EDIT.
+
+package form3mock
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+
+	form3 "{{.Form3Import}}"
+)
+{{range $mock := .Mocks}}
+// {{.Name}} is a mock of form3.{{.Name}}.
+type {{.Name}} struct {
+	Mock
+}
+
+var _ form3.{{.Name}} = (*{{.Name}})(nil)
+
+// New{{.Name}} creates a mock of form3.{{.Name}}. Expectations that were not met are reported when the test finishes.
+func New{{.Name}}(t testing.TB) *{{.Name}} {
+	m := &{{.Name}}{}
+	m.t = t
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+{{range .Methods}}
+// {{.Name}} mocks form3.{{$mock.Name}}.{{.Name}}.
+func (m *{{$mock.Name}}) {{.Name}}({{.Params}}) {{if gt (len .Results) 1}}({{join .Results ", "}}){{else}}{{index .Results 0}}{{end}} {
+{{- if .Chain}}
+	m.record("{{.Name}}"{{if .Args}}, {{.Args}}{{end}})
+	return m
+{{- else}}
+	returns := m.called("{{.Name}}"{{if .Args}}, {{.Args}}{{end}})
+	return {{range $i, $r := .Results}}{{if $i}}, {{end}}value[{{$r}}](returns, {{$i}}){{end}}
+{{- end}}
+}
+{{end}}{{end}}`))
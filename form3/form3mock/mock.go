@@ -0,0 +1,209 @@
+// Package form3mock provides mocks of the form3 service interfaces (AccountsAPI, ...) for testing code that uses the client
+// without a server.
+//
+// Set expectations with On, naming the method and its arguments (the context is left out), and the values to return:
+//
+//	accounts := form3mock.NewAccountsAPI(t)
+//	accounts.On("Fetch", "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc").Return(&form3.Account{...}, nil, nil)
+//
+//	svc := NewOnboarding(accounts) // depends on form3.AccountsAPI
+//
+// Unexpected calls fail the test, and expectations that were not met are reported when the test finishes.
+// Every call is recorded and can be inspected with Calls. Chainable methods such as Number and Filter
+// need no expectations: they are recorded and return the mock.
+package form3mock
+
+//go:generate go run ./internal/mockgen -source ../interfaces.go -output mocks_gen.go
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// Any matches any argument in On.
+var Any = anyArgument{}
+
+type anyArgument struct{}
+
+// Matcher matches an argument in On when it returns true, e.g.
+//
+//	On("Create", form3mock.Matcher(func(v interface{}) bool { return v.(*form3.Account).Attributes.Country == "GB" }))
+type Matcher func(arg interface{}) bool
+
+// Call is a recorded call to a mock.
+type Call struct {
+	Method string
+	Args   []interface{} // the arguments, without the context
+}
+
+// Expectation is an expected call to a mock, set with On.
+type Expectation struct {
+	method  string
+	args    []interface{}
+	returns []interface{}
+	run     func(args []interface{})
+	times   int // 0 for any number of times
+	maybe   bool
+	calls   int
+}
+
+// Return sets the values returned by the call, in order. Values left out are returned as zero values.
+func (e *Expectation) Return(values ...interface{}) *Expectation {
+	e.returns = values
+	return e
+}
+
+// Run sets a function called with the arguments of the call before it returns, e.g. to capture them.
+func (e *Expectation) Run(fn func(args []interface{})) *Expectation {
+	e.run = fn
+	return e
+}
+
+// Times sets how many times the call is expected. Once met, further calls are matched by later expectations.
+func (e *Expectation) Times(times int) *Expectation {
+	e.times = times
+	return e
+}
+
+// Once expects the call exactly once.
+func (e *Expectation) Once() *Expectation {
+	return e.Times(1)
+}
+
+// Maybe makes the call optional, so it is not reported if it never happens.
+func (e *Expectation) Maybe() *Expectation {
+	e.maybe = true
+	return e
+}
+
+func (e *Expectation) matches(method string, args []interface{}) bool {
+	if e.method != method || (e.times > 0 && e.calls >= e.times) || len(e.args) > len(args) {
+		return false
+	}
+
+	// Trailing arguments left out of On, such as request options, match anything.
+	for i, expected := range e.args {
+		switch expected := expected.(type) {
+		case anyArgument:
+		case Matcher:
+			if !expected(args[i]) {
+				return false
+			}
+		default:
+			if !reflect.DeepEqual(expected, args[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Mock holds the expectations and calls of a mock. It is embedded in every mock of this package.
+type Mock struct {
+	t testing.TB
+
+	mu           sync.Mutex
+	expectations []*Expectation
+	calls        []Call
+}
+
+// On expects a call to method with args, leaving out the context.
+func (m *Mock) On(method string, args ...interface{}) *Expectation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e := &Expectation{method: method, args: args}
+	m.expectations = append(m.expectations, e)
+	return e
+}
+
+// Calls returns the recorded calls to method, or every call if method is "".
+func (m *Mock) Calls(method string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var calls []Call
+	for _, call := range m.calls {
+		if method == "" || call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// AssertExpectations reports the expectations that were not met. Mocks created with a testing.TB call it when the test finishes.
+func (m *Mock) AssertExpectations(t testing.TB) {
+	t.Helper()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, e := range m.expectations {
+		switch {
+		case e.maybe:
+		case e.times > 0 && e.calls != e.times:
+			t.Errorf("form3mock: expected %s%v to be called %d times, got %d", e.method, e.args, e.times, e.calls)
+		case e.calls == 0:
+			t.Errorf("form3mock: expected %s%v to be called", e.method, e.args)
+		}
+	}
+}
+
+// called records a call and returns the values of the first expectation it matches.
+func (m *Mock) called(method string, args ...interface{}) []interface{} {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+
+	var matched *Expectation
+	for _, e := range m.expectations {
+		if e.matches(method, args) {
+			matched = e
+			break
+		}
+	}
+	if matched == nil {
+		m.mu.Unlock()
+		m.fail("form3mock: unexpected call %s%v", method, args)
+		return nil
+	}
+
+	matched.calls++
+	m.mu.Unlock()
+
+	if matched.run != nil {
+		matched.run(args)
+	}
+	return matched.returns
+}
+
+// record records a call to a chainable method, which needs no expectation.
+func (m *Mock) record(method string, args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+}
+
+func (m *Mock) fail(format string, args ...interface{}) {
+	if m.t == nil {
+		panic(fmt.Sprintf(format, args...))
+	}
+	m.t.Helper()
+	m.t.Errorf(format, args...)
+}
+
+// value returns returns[i] as a T, or the zero value of T if it was left out or nil.
+func value[T any](returns []interface{}, i int) T {
+	var zero T
+	if i >= len(returns) || returns[i] == nil {
+		return zero
+	}
+
+	v, ok := returns[i].(T)
+	if !ok {
+		panic(fmt.Sprintf("form3mock: return value %d is a %T, not a %T", i, returns[i], zero))
+	}
+	return v
+}
@@ -0,0 +1,100 @@
+package form3mock
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+)
+
+// openAccount stands in for consumer code depending on form3.AccountsAPI.
+func openAccount(ctx context.Context, accounts form3.AccountsAPI, account *form3.Account) (*form3.Account, error) {
+	if err := accounts.CheckRegistration(ctx, account); err != nil {
+		return nil, err
+	}
+	created, _, err := accounts.Create(ctx, account)
+	return created, err
+}
+
+func Test_AccountsAPI_Expectations_Success(t *testing.T) {
+	accounts := NewAccountsAPI(t)
+	account := &form3.Account{ID: "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"}
+
+	accounts.On("CheckRegistration", account).Return(nil).Once()
+	accounts.On("Create", Matcher(func(v interface{}) bool {
+		return v.(*form3.Account).ID == account.ID
+	})).Return(account, nil, nil)
+
+	created, err := openAccount(context.Background(), accounts, account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created != account {
+		t.Error("Expected:", account, "Got:", created)
+	}
+
+	if calls := accounts.Calls(""); len(calls) != 2 || calls[1].Method != "Create" {
+		t.Error("Expected: CheckRegistration then Create", "Got:", calls)
+	}
+}
+
+func Test_AccountsAPI_ReturnsError_Success(t *testing.T) {
+	accounts := NewAccountsAPI(t)
+	accounts.On("CheckRegistration", Any).Return(form3.ErrBankIDNotRegistered)
+
+	_, err := openAccount(context.Background(), accounts, &form3.Account{})
+	if !errors.Is(err, form3.ErrBankIDNotRegistered) {
+		t.Error("Expected:", form3.ErrBankIDNotRegistered, "Got:", err)
+	}
+
+	if len(accounts.Calls("Create")) != 0 {
+		t.Error("Expected: no call to Create", "Got:", accounts.Calls("Create"))
+	}
+}
+
+func Test_AccountsAPI_Chain_Success(t *testing.T) {
+	accounts := NewAccountsAPI(t)
+	accounts.On("List").Return([]form3.Account{{ID: "a"}}, nil, nil)
+
+	list, _, err := accounts.Filter("country", "GB").Size(20).List(context.Background())
+	if err != nil || len(list) != 1 {
+		t.Fatal("Expected: 1 account", "Got:", list, err)
+	}
+
+	filter := accounts.Calls("Filter")
+	if len(filter) != 1 || !reflect.DeepEqual(filter[0].Args, []interface{}{"country", []string{"GB"}}) {
+		t.Error("Expected: Filter(country, [GB])", "Got:", filter)
+	}
+}
+
+func Test_Mock_UnmetExpectations_Failure(t *testing.T) {
+	rec := &recordingTB{TB: t}
+
+	accounts := &AccountsAPI{}
+	accounts.t = rec
+	accounts.On("Fetch", "a").Return(nil, nil, nil).Times(2)
+	accounts.On("Delete", "b", 0)
+	accounts.On("List").Maybe()
+
+	accounts.Fetch(context.Background(), "a")
+	accounts.Fetch(context.Background(), "c")
+	accounts.AssertExpectations(rec)
+
+	if len(rec.errors) != 3 {
+		t.Error("Expected: an unexpected call and 2 unmet expectations", "Got:", rec.errors)
+	}
+}
+
+// recordingTB records the errors reported to it instead of failing the test.
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, format)
+}
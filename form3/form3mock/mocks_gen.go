@@ -0,0 +1,718 @@
+// Code generated by mockgen from form3/interfaces.go. DO NOT EDIT.
+
+package form3mock
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+)
+
+// AccountsAPI is a mock of form3.AccountsAPI.
+type AccountsAPI struct {
+	Mock
+}
+
+var _ form3.AccountsAPI = (*AccountsAPI)(nil)
+
+// NewAccountsAPI creates a mock of form3.AccountsAPI. Expectations that were not met are reported when the test finishes.
+func NewAccountsAPI(t testing.TB) *AccountsAPI {
+	m := &AccountsAPI{}
+	m.t = t
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+// Fetch mocks form3.AccountsAPI.Fetch.
+func (m *AccountsAPI) Fetch(ctx context.Context, id string, options ...form3.RequestOption) (*form3.Account, *http.Response, error) {
+	returns := m.called("Fetch", id, options)
+	return value[*form3.Account](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// List mocks form3.AccountsAPI.List.
+func (m *AccountsAPI) List(ctx context.Context, options ...form3.RequestOption) ([]form3.Account, *http.Response, error) {
+	returns := m.called("List", options)
+	return value[[]form3.Account](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// ListAll mocks form3.AccountsAPI.ListAll.
+func (m *AccountsAPI) ListAll(ctx context.Context, options ...form3.RequestOption) ([]form3.Account, *http.Response, error) {
+	returns := m.called("ListAll", options)
+	return value[[]form3.Account](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Create mocks form3.AccountsAPI.Create.
+func (m *AccountsAPI) Create(ctx context.Context, account *form3.Account) (*form3.Account, *http.Response, error) {
+	returns := m.called("Create", account)
+	return value[*form3.Account](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Update mocks form3.AccountsAPI.Update.
+func (m *AccountsAPI) Update(ctx context.Context, account *form3.Account) (*form3.Account, *http.Response, error) {
+	returns := m.called("Update", account)
+	return value[*form3.Account](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Delete mocks form3.AccountsAPI.Delete.
+func (m *AccountsAPI) Delete(ctx context.Context, id string, version int) (bool, *http.Response, error) {
+	returns := m.called("Delete", id, version)
+	return value[bool](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// CheckRegistration mocks form3.AccountsAPI.CheckRegistration.
+func (m *AccountsAPI) CheckRegistration(ctx context.Context, account *form3.Account) error {
+	returns := m.called("CheckRegistration", account)
+	return value[error](returns, 0)
+}
+
+// History mocks form3.AccountsAPI.History.
+func (m *AccountsAPI) History(ctx context.Context, id string) ([]form3.AccountChange, *http.Response, error) {
+	returns := m.called("History", id)
+	return value[[]form3.AccountChange](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Number mocks form3.AccountsAPI.Number.
+func (m *AccountsAPI) Number(number int) form3.AccountsAPI {
+	m.record("Number", number)
+	return m
+}
+
+// Size mocks form3.AccountsAPI.Size.
+func (m *AccountsAPI) Size(size int) form3.AccountsAPI {
+	m.record("Size", size)
+	return m
+}
+
+// Filter mocks form3.AccountsAPI.Filter.
+func (m *AccountsAPI) Filter(attribute string, values ...string) form3.AccountsAPI {
+	m.record("Filter", attribute, values)
+	return m
+}
+
+// SubscriptionsAPI is a mock of form3.SubscriptionsAPI.
+type SubscriptionsAPI struct {
+	Mock
+}
+
+var _ form3.SubscriptionsAPI = (*SubscriptionsAPI)(nil)
+
+// NewSubscriptionsAPI creates a mock of form3.SubscriptionsAPI. Expectations that were not met are reported when the test finishes.
+func NewSubscriptionsAPI(t testing.TB) *SubscriptionsAPI {
+	m := &SubscriptionsAPI{}
+	m.t = t
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+// Fetch mocks form3.SubscriptionsAPI.Fetch.
+func (m *SubscriptionsAPI) Fetch(ctx context.Context, id string) (*form3.Subscription, *http.Response, error) {
+	returns := m.called("Fetch", id)
+	return value[*form3.Subscription](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// List mocks form3.SubscriptionsAPI.List.
+func (m *SubscriptionsAPI) List(ctx context.Context) ([]form3.Subscription, *http.Response, error) {
+	returns := m.called("List")
+	return value[[]form3.Subscription](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Create mocks form3.SubscriptionsAPI.Create.
+func (m *SubscriptionsAPI) Create(ctx context.Context, subscription *form3.Subscription) (*form3.Subscription, *http.Response, error) {
+	returns := m.called("Create", subscription)
+	return value[*form3.Subscription](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Update mocks form3.SubscriptionsAPI.Update.
+func (m *SubscriptionsAPI) Update(ctx context.Context, subscription *form3.Subscription) (*form3.Subscription, *http.Response, error) {
+	returns := m.called("Update", subscription)
+	return value[*form3.Subscription](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Deactivate mocks form3.SubscriptionsAPI.Deactivate.
+func (m *SubscriptionsAPI) Deactivate(ctx context.Context, subscription *form3.Subscription) (*form3.Subscription, *http.Response, error) {
+	returns := m.called("Deactivate", subscription)
+	return value[*form3.Subscription](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Delete mocks form3.SubscriptionsAPI.Delete.
+func (m *SubscriptionsAPI) Delete(ctx context.Context, id string, version int) (bool, *http.Response, error) {
+	returns := m.called("Delete", id, version)
+	return value[bool](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Number mocks form3.SubscriptionsAPI.Number.
+func (m *SubscriptionsAPI) Number(number int) form3.SubscriptionsAPI {
+	m.record("Number", number)
+	return m
+}
+
+// Size mocks form3.SubscriptionsAPI.Size.
+func (m *SubscriptionsAPI) Size(size int) form3.SubscriptionsAPI {
+	m.record("Size", size)
+	return m
+}
+
+// OrganisationsAPI is a mock of form3.OrganisationsAPI.
+type OrganisationsAPI struct {
+	Mock
+}
+
+var _ form3.OrganisationsAPI = (*OrganisationsAPI)(nil)
+
+// NewOrganisationsAPI creates a mock of form3.OrganisationsAPI. Expectations that were not met are reported when the test finishes.
+func NewOrganisationsAPI(t testing.TB) *OrganisationsAPI {
+	m := &OrganisationsAPI{}
+	m.t = t
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+// Fetch mocks form3.OrganisationsAPI.Fetch.
+func (m *OrganisationsAPI) Fetch(ctx context.Context, id string) (*form3.Organisation, *http.Response, error) {
+	returns := m.called("Fetch", id)
+	return value[*form3.Organisation](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// List mocks form3.OrganisationsAPI.List.
+func (m *OrganisationsAPI) List(ctx context.Context) ([]form3.Organisation, *http.Response, error) {
+	returns := m.called("List")
+	return value[[]form3.Organisation](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Children mocks form3.OrganisationsAPI.Children.
+func (m *OrganisationsAPI) Children(ctx context.Context, parentID string) ([]form3.Organisation, *http.Response, error) {
+	returns := m.called("Children", parentID)
+	return value[[]form3.Organisation](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Create mocks form3.OrganisationsAPI.Create.
+func (m *OrganisationsAPI) Create(ctx context.Context, organisation *form3.Organisation) (*form3.Organisation, *http.Response, error) {
+	returns := m.called("Create", organisation)
+	return value[*form3.Organisation](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Update mocks form3.OrganisationsAPI.Update.
+func (m *OrganisationsAPI) Update(ctx context.Context, organisation *form3.Organisation) (*form3.Organisation, *http.Response, error) {
+	returns := m.called("Update", organisation)
+	return value[*form3.Organisation](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Walk mocks form3.OrganisationsAPI.Walk.
+func (m *OrganisationsAPI) Walk(ctx context.Context, rootID string, fn form3.WalkFunc) error {
+	returns := m.called("Walk", rootID, fn)
+	return value[error](returns, 0)
+}
+
+// Number mocks form3.OrganisationsAPI.Number.
+func (m *OrganisationsAPI) Number(number int) form3.OrganisationsAPI {
+	m.record("Number", number)
+	return m
+}
+
+// Size mocks form3.OrganisationsAPI.Size.
+func (m *OrganisationsAPI) Size(size int) form3.OrganisationsAPI {
+	m.record("Size", size)
+	return m
+}
+
+// BankIDsAPI is a mock of form3.BankIDsAPI.
+type BankIDsAPI struct {
+	Mock
+}
+
+var _ form3.BankIDsAPI = (*BankIDsAPI)(nil)
+
+// NewBankIDsAPI creates a mock of form3.BankIDsAPI. Expectations that were not met are reported when the test finishes.
+func NewBankIDsAPI(t testing.TB) *BankIDsAPI {
+	m := &BankIDsAPI{}
+	m.t = t
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+// Fetch mocks form3.BankIDsAPI.Fetch.
+func (m *BankIDsAPI) Fetch(ctx context.Context, id string) (*form3.BankID, *http.Response, error) {
+	returns := m.called("Fetch", id)
+	return value[*form3.BankID](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// List mocks form3.BankIDsAPI.List.
+func (m *BankIDsAPI) List(ctx context.Context) ([]form3.BankID, *http.Response, error) {
+	returns := m.called("List")
+	return value[[]form3.BankID](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Create mocks form3.BankIDsAPI.Create.
+func (m *BankIDsAPI) Create(ctx context.Context, bankID *form3.BankID) (*form3.BankID, *http.Response, error) {
+	returns := m.called("Create", bankID)
+	return value[*form3.BankID](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Delete mocks form3.BankIDsAPI.Delete.
+func (m *BankIDsAPI) Delete(ctx context.Context, id string, version int) (bool, *http.Response, error) {
+	returns := m.called("Delete", id, version)
+	return value[bool](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// IsRegistered mocks form3.BankIDsAPI.IsRegistered.
+func (m *BankIDsAPI) IsRegistered(ctx context.Context, organisationID string, bankID string, bankIDCode string) (bool, error) {
+	returns := m.called("IsRegistered", organisationID, bankID, bankIDCode)
+	return value[bool](returns, 0), value[error](returns, 1)
+}
+
+// Number mocks form3.BankIDsAPI.Number.
+func (m *BankIDsAPI) Number(number int) form3.BankIDsAPI {
+	m.record("Number", number)
+	return m
+}
+
+// Size mocks form3.BankIDsAPI.Size.
+func (m *BankIDsAPI) Size(size int) form3.BankIDsAPI {
+	m.record("Size", size)
+	return m
+}
+
+// BicsAPI is a mock of form3.BicsAPI.
+type BicsAPI struct {
+	Mock
+}
+
+var _ form3.BicsAPI = (*BicsAPI)(nil)
+
+// NewBicsAPI creates a mock of form3.BicsAPI. Expectations that were not met are reported when the test finishes.
+func NewBicsAPI(t testing.TB) *BicsAPI {
+	m := &BicsAPI{}
+	m.t = t
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+// Fetch mocks form3.BicsAPI.Fetch.
+func (m *BicsAPI) Fetch(ctx context.Context, id string) (*form3.Bic, *http.Response, error) {
+	returns := m.called("Fetch", id)
+	return value[*form3.Bic](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// List mocks form3.BicsAPI.List.
+func (m *BicsAPI) List(ctx context.Context) ([]form3.Bic, *http.Response, error) {
+	returns := m.called("List")
+	return value[[]form3.Bic](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Create mocks form3.BicsAPI.Create.
+func (m *BicsAPI) Create(ctx context.Context, bic *form3.Bic) (*form3.Bic, *http.Response, error) {
+	returns := m.called("Create", bic)
+	return value[*form3.Bic](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Delete mocks form3.BicsAPI.Delete.
+func (m *BicsAPI) Delete(ctx context.Context, id string, version int) (bool, *http.Response, error) {
+	returns := m.called("Delete", id, version)
+	return value[bool](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// IsRegistered mocks form3.BicsAPI.IsRegistered.
+func (m *BicsAPI) IsRegistered(ctx context.Context, organisationID string, bic string) (bool, error) {
+	returns := m.called("IsRegistered", organisationID, bic)
+	return value[bool](returns, 0), value[error](returns, 1)
+}
+
+// Number mocks form3.BicsAPI.Number.
+func (m *BicsAPI) Number(number int) form3.BicsAPI {
+	m.record("Number", number)
+	return m
+}
+
+// Size mocks form3.BicsAPI.Size.
+func (m *BicsAPI) Size(size int) form3.BicsAPI {
+	m.record("Size", size)
+	return m
+}
+
+// SecurityAPI is a mock of form3.SecurityAPI.
+type SecurityAPI struct {
+	Mock
+}
+
+var _ form3.SecurityAPI = (*SecurityAPI)(nil)
+
+// NewSecurityAPI creates a mock of form3.SecurityAPI. Expectations that were not met are reported when the test finishes.
+func NewSecurityAPI(t testing.TB) *SecurityAPI {
+	m := &SecurityAPI{}
+	m.t = t
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+// FetchUser mocks form3.SecurityAPI.FetchUser.
+func (m *SecurityAPI) FetchUser(ctx context.Context, id string) (*form3.User, *http.Response, error) {
+	returns := m.called("FetchUser", id)
+	return value[*form3.User](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// ListUsers mocks form3.SecurityAPI.ListUsers.
+func (m *SecurityAPI) ListUsers(ctx context.Context) ([]form3.User, *http.Response, error) {
+	returns := m.called("ListUsers")
+	return value[[]form3.User](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// CreateUser mocks form3.SecurityAPI.CreateUser.
+func (m *SecurityAPI) CreateUser(ctx context.Context, user *form3.User) (*form3.User, *http.Response, error) {
+	returns := m.called("CreateUser", user)
+	return value[*form3.User](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// DeleteUser mocks form3.SecurityAPI.DeleteUser.
+func (m *SecurityAPI) DeleteUser(ctx context.Context, id string, version int) (bool, *http.Response, error) {
+	returns := m.called("DeleteUser", id, version)
+	return value[bool](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// FetchRole mocks form3.SecurityAPI.FetchRole.
+func (m *SecurityAPI) FetchRole(ctx context.Context, id string) (*form3.Role, *http.Response, error) {
+	returns := m.called("FetchRole", id)
+	return value[*form3.Role](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// ListRoles mocks form3.SecurityAPI.ListRoles.
+func (m *SecurityAPI) ListRoles(ctx context.Context) ([]form3.Role, *http.Response, error) {
+	returns := m.called("ListRoles")
+	return value[[]form3.Role](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// CreateRole mocks form3.SecurityAPI.CreateRole.
+func (m *SecurityAPI) CreateRole(ctx context.Context, role *form3.Role) (*form3.Role, *http.Response, error) {
+	returns := m.called("CreateRole", role)
+	return value[*form3.Role](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// DeleteRole mocks form3.SecurityAPI.DeleteRole.
+func (m *SecurityAPI) DeleteRole(ctx context.Context, id string, version int) (bool, *http.Response, error) {
+	returns := m.called("DeleteRole", id, version)
+	return value[bool](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// ListACEs mocks form3.SecurityAPI.ListACEs.
+func (m *SecurityAPI) ListACEs(ctx context.Context, roleID string) ([]form3.ACE, *http.Response, error) {
+	returns := m.called("ListACEs", roleID)
+	return value[[]form3.ACE](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// CreateACE mocks form3.SecurityAPI.CreateACE.
+func (m *SecurityAPI) CreateACE(ctx context.Context, roleID string, ace *form3.ACE) (*form3.ACE, *http.Response, error) {
+	returns := m.called("CreateACE", roleID, ace)
+	return value[*form3.ACE](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// DeleteACE mocks form3.SecurityAPI.DeleteACE.
+func (m *SecurityAPI) DeleteACE(ctx context.Context, roleID string, id string, version int) (bool, *http.Response, error) {
+	returns := m.called("DeleteACE", roleID, id, version)
+	return value[bool](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// ListCredentials mocks form3.SecurityAPI.ListCredentials.
+func (m *SecurityAPI) ListCredentials(ctx context.Context, userID string) ([]form3.Credential, *http.Response, error) {
+	returns := m.called("ListCredentials", userID)
+	return value[[]form3.Credential](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// UploadPublicKey mocks form3.SecurityAPI.UploadPublicKey.
+func (m *SecurityAPI) UploadPublicKey(ctx context.Context, userID string, publicKeyPEM string) (*form3.Credential, *http.Response, error) {
+	returns := m.called("UploadPublicKey", userID, publicKeyPEM)
+	return value[*form3.Credential](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// DeleteCredential mocks form3.SecurityAPI.DeleteCredential.
+func (m *SecurityAPI) DeleteCredential(ctx context.Context, userID string, id string) (bool, *http.Response, error) {
+	returns := m.called("DeleteCredential", userID, id)
+	return value[bool](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Diff mocks form3.SecurityAPI.Diff.
+func (m *SecurityAPI) Diff(ctx context.Context, organisationID string, desired []form3.RoleSpec, prune bool) (*form3.SecurityPlan, error) {
+	returns := m.called("Diff", organisationID, desired, prune)
+	return value[*form3.SecurityPlan](returns, 0), value[error](returns, 1)
+}
+
+// Apply mocks form3.SecurityAPI.Apply.
+func (m *SecurityAPI) Apply(ctx context.Context, plan *form3.SecurityPlan) error {
+	returns := m.called("Apply", plan)
+	return value[error](returns, 0)
+}
+
+// Converge mocks form3.SecurityAPI.Converge.
+func (m *SecurityAPI) Converge(ctx context.Context, organisationID string, desired []form3.RoleSpec, prune bool) (*form3.SecurityPlan, error) {
+	returns := m.called("Converge", organisationID, desired, prune)
+	return value[*form3.SecurityPlan](returns, 0), value[error](returns, 1)
+}
+
+// Number mocks form3.SecurityAPI.Number.
+func (m *SecurityAPI) Number(number int) form3.SecurityAPI {
+	m.record("Number", number)
+	return m
+}
+
+// Size mocks form3.SecurityAPI.Size.
+func (m *SecurityAPI) Size(size int) form3.SecurityAPI {
+	m.record("Size", size)
+	return m
+}
+
+// ReportsAPI is a mock of form3.ReportsAPI.
+type ReportsAPI struct {
+	Mock
+}
+
+var _ form3.ReportsAPI = (*ReportsAPI)(nil)
+
+// NewReportsAPI creates a mock of form3.ReportsAPI. Expectations that were not met are reported when the test finishes.
+func NewReportsAPI(t testing.TB) *ReportsAPI {
+	m := &ReportsAPI{}
+	m.t = t
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+// Request mocks form3.ReportsAPI.Request.
+func (m *ReportsAPI) Request(ctx context.Context, report *form3.Report) (*form3.Report, *http.Response, error) {
+	returns := m.called("Request", report)
+	return value[*form3.Report](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Fetch mocks form3.ReportsAPI.Fetch.
+func (m *ReportsAPI) Fetch(ctx context.Context, id string) (*form3.Report, *http.Response, error) {
+	returns := m.called("Fetch", id)
+	return value[*form3.Report](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Wait mocks form3.ReportsAPI.Wait.
+func (m *ReportsAPI) Wait(ctx context.Context, id string) (*form3.Report, error) {
+	returns := m.called("Wait", id)
+	return value[*form3.Report](returns, 0), value[error](returns, 1)
+}
+
+// Download mocks form3.ReportsAPI.Download.
+func (m *ReportsAPI) Download(ctx context.Context, report *form3.Report, w io.Writer) (int64, *http.Response, error) {
+	returns := m.called("Download", report, w)
+	return value[int64](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// PollInterval mocks form3.ReportsAPI.PollInterval.
+func (m *ReportsAPI) PollInterval(interval time.Duration) form3.ReportsAPI {
+	m.record("PollInterval", interval)
+	return m
+}
+
+// Attempts mocks form3.ReportsAPI.Attempts.
+func (m *ReportsAPI) Attempts(attempts int) form3.ReportsAPI {
+	m.record("Attempts", attempts)
+	return m
+}
+
+// AuditAPI is a mock of form3.AuditAPI.
+type AuditAPI struct {
+	Mock
+}
+
+var _ form3.AuditAPI = (*AuditAPI)(nil)
+
+// NewAuditAPI creates a mock of form3.AuditAPI. Expectations that were not met are reported when the test finishes.
+func NewAuditAPI(t testing.TB) *AuditAPI {
+	m := &AuditAPI{}
+	m.t = t
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+// List mocks form3.AuditAPI.List.
+func (m *AuditAPI) List(ctx context.Context) ([]form3.AuditEntry, *http.Response, error) {
+	returns := m.called("List")
+	return value[[]form3.AuditEntry](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// ListAll mocks form3.AuditAPI.ListAll.
+func (m *AuditAPI) ListAll(ctx context.Context) ([]form3.AuditEntry, *http.Response, error) {
+	returns := m.called("ListAll")
+	return value[[]form3.AuditEntry](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// RecordType mocks form3.AuditAPI.RecordType.
+func (m *AuditAPI) RecordType(recordType string) form3.AuditAPI {
+	m.record("RecordType", recordType)
+	return m
+}
+
+// RecordID mocks form3.AuditAPI.RecordID.
+func (m *AuditAPI) RecordID(recordID string) form3.AuditAPI {
+	m.record("RecordID", recordID)
+	return m
+}
+
+// Actor mocks form3.AuditAPI.Actor.
+func (m *AuditAPI) Actor(userID string) form3.AuditAPI {
+	m.record("Actor", userID)
+	return m
+}
+
+// Between mocks form3.AuditAPI.Between.
+func (m *AuditAPI) Between(from time.Time, to time.Time) form3.AuditAPI {
+	m.record("Between", from, to)
+	return m
+}
+
+// Number mocks form3.AuditAPI.Number.
+func (m *AuditAPI) Number(number int) form3.AuditAPI {
+	m.record("Number", number)
+	return m
+}
+
+// Size mocks form3.AuditAPI.Size.
+func (m *AuditAPI) Size(size int) form3.AuditAPI {
+	m.record("Size", size)
+	return m
+}
+
+// LimitsAPI is a mock of form3.LimitsAPI.
+type LimitsAPI struct {
+	Mock
+}
+
+var _ form3.LimitsAPI = (*LimitsAPI)(nil)
+
+// NewLimitsAPI creates a mock of form3.LimitsAPI. Expectations that were not met are reported when the test finishes.
+func NewLimitsAPI(t testing.TB) *LimitsAPI {
+	m := &LimitsAPI{}
+	m.t = t
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+// Fetch mocks form3.LimitsAPI.Fetch.
+func (m *LimitsAPI) Fetch(ctx context.Context, id string) (*form3.Limit, *http.Response, error) {
+	returns := m.called("Fetch", id)
+	return value[*form3.Limit](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// List mocks form3.LimitsAPI.List.
+func (m *LimitsAPI) List(ctx context.Context) ([]form3.Limit, *http.Response, error) {
+	returns := m.called("List")
+	return value[[]form3.Limit](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// ListForScheme mocks form3.LimitsAPI.ListForScheme.
+func (m *LimitsAPI) ListForScheme(ctx context.Context, scheme string) ([]form3.Limit, *http.Response, error) {
+	returns := m.called("ListForScheme", scheme)
+	return value[[]form3.Limit](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// CanSend mocks form3.LimitsAPI.CanSend.
+func (m *LimitsAPI) CanSend(ctx context.Context, scheme string, amount form3.Amount, currency form3.Currency) (bool, error) {
+	returns := m.called("CanSend", scheme, amount, currency)
+	return value[bool](returns, 0), value[error](returns, 1)
+}
+
+// Number mocks form3.LimitsAPI.Number.
+func (m *LimitsAPI) Number(number int) form3.LimitsAPI {
+	m.record("Number", number)
+	return m
+}
+
+// Size mocks form3.LimitsAPI.Size.
+func (m *LimitsAPI) Size(size int) form3.LimitsAPI {
+	m.record("Size", size)
+	return m
+}
+
+// ClaimsAPI is a mock of form3.ClaimsAPI.
+type ClaimsAPI struct {
+	Mock
+}
+
+var _ form3.ClaimsAPI = (*ClaimsAPI)(nil)
+
+// NewClaimsAPI creates a mock of form3.ClaimsAPI. Expectations that were not met are reported when the test finishes.
+func NewClaimsAPI(t testing.TB) *ClaimsAPI {
+	m := &ClaimsAPI{}
+	m.t = t
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+// Fetch mocks form3.ClaimsAPI.Fetch.
+func (m *ClaimsAPI) Fetch(ctx context.Context, id string, options ...form3.RequestOption) (*form3.Claim, *http.Response, error) {
+	returns := m.called("Fetch", id, options)
+	return value[*form3.Claim](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// List mocks form3.ClaimsAPI.List.
+func (m *ClaimsAPI) List(ctx context.Context) ([]form3.Claim, *http.Response, error) {
+	returns := m.called("List")
+	return value[[]form3.Claim](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Create mocks form3.ClaimsAPI.Create.
+func (m *ClaimsAPI) Create(ctx context.Context, claim *form3.Claim) (*form3.Claim, *http.Response, error) {
+	returns := m.called("Create", claim)
+	return value[*form3.Claim](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Submit mocks form3.ClaimsAPI.Submit.
+func (m *ClaimsAPI) Submit(ctx context.Context, claim *form3.Claim) (*form3.ClaimSubmission, *http.Response, error) {
+	returns := m.called("Submit", claim)
+	return value[*form3.ClaimSubmission](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// FetchSubmission mocks form3.ClaimsAPI.FetchSubmission.
+func (m *ClaimsAPI) FetchSubmission(ctx context.Context, claimID string, id string) (*form3.ClaimSubmission, *http.Response, error) {
+	returns := m.called("FetchSubmission", claimID, id)
+	return value[*form3.ClaimSubmission](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// ListSubmissions mocks form3.ClaimsAPI.ListSubmissions.
+func (m *ClaimsAPI) ListSubmissions(ctx context.Context, claimID string) ([]form3.ClaimSubmission, *http.Response, error) {
+	returns := m.called("ListSubmissions", claimID)
+	return value[[]form3.ClaimSubmission](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Number mocks form3.ClaimsAPI.Number.
+func (m *ClaimsAPI) Number(number int) form3.ClaimsAPI {
+	m.record("Number", number)
+	return m
+}
+
+// Size mocks form3.ClaimsAPI.Size.
+func (m *ClaimsAPI) Size(size int) form3.ClaimsAPI {
+	m.record("Size", size)
+	return m
+}
+
+// RoutingAPI is a mock of form3.RoutingAPI.
+type RoutingAPI struct {
+	Mock
+}
+
+var _ form3.RoutingAPI = (*RoutingAPI)(nil)
+
+// NewRoutingAPI creates a mock of form3.RoutingAPI. Expectations that were not met are reported when the test finishes.
+func NewRoutingAPI(t testing.TB) *RoutingAPI {
+	m := &RoutingAPI{}
+	m.t = t
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+// Lookup mocks form3.RoutingAPI.Lookup.
+func (m *RoutingAPI) Lookup(ctx context.Context, attributes form3.AccountAttributes) (*form3.Route, *http.Response, error) {
+	returns := m.called("Lookup", attributes)
+	return value[*form3.Route](returns, 0), value[*http.Response](returns, 1), value[error](returns, 2)
+}
+
+// Reachable mocks form3.RoutingAPI.Reachable.
+func (m *RoutingAPI) Reachable(ctx context.Context, attributes form3.AccountAttributes, scheme string) (bool, error) {
+	returns := m.called("Reachable", attributes, scheme)
+	return value[bool](returns, 0), value[error](returns, 1)
+}
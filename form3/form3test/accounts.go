@@ -0,0 +1,153 @@
+package form3test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+)
+
+func (f *Fake) list(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	var matched []form3.Account
+	for _, id := range f.order {
+		account := f.accounts[id]
+		if matchesFilters(params, func(name string) string { return accountValue(account, name) }) {
+			matched = append(matched, account)
+		}
+	}
+
+	writePage(w, params, accountsPath, matched)
+}
+
+func (f *Fake) create(w http.ResponseWriter, r *http.Request) {
+	var payload form3.DataEnvelope[form3.Account]
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	account := payload.Data
+	if failures := validateAccount(&account); len(failures) > 0 {
+		writeValidationError(w, failures)
+		return
+	}
+
+	if _, ok := f.accounts[account.ID]; ok {
+		writeError(w, http.StatusConflict, "Account cannot be created as it violates a duplicate constraint")
+		return
+	}
+
+	account.Version = 0
+	f.accounts[account.ID] = account
+	f.order = append(f.order, account.ID)
+
+	writeJSON(w, http.StatusCreated, form3.DataEnvelope[form3.Account]{Data: account, Links: selfLink(accountsPath, account.ID)})
+}
+
+func (f *Fake) fetch(w http.ResponseWriter, id string) {
+	account, ok := f.accounts[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("record %s does not exist", id))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, form3.DataEnvelope[form3.Account]{Data: account, Links: selfLink(accountsPath, id)})
+}
+
+func (f *Fake) update(w http.ResponseWriter, r *http.Request, id string) {
+	var payload form3.DataEnvelope[form3.Account]
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return
+	}
+
+	account := payload.Data
+	if account.ID != id {
+		writeError(w, http.StatusBadRequest, "id in body does not match id in path")
+		return
+	}
+
+	stored, ok := f.accounts[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("record %s does not exist", id))
+		return
+	}
+	if account.Version != stored.Version {
+		writeError(w, http.StatusConflict, "invalid version")
+		return
+	}
+
+	if failures := validateAccount(&account); len(failures) > 0 {
+		writeValidationError(w, failures)
+		return
+	}
+
+	account.Version = stored.Version + 1
+	f.accounts[id] = account
+
+	writeJSON(w, http.StatusOK, form3.DataEnvelope[form3.Account]{Data: account, Links: selfLink(accountsPath, id)})
+}
+
+func (f *Fake) delete(w http.ResponseWriter, r *http.Request, id string) {
+	version, err := strconv.Atoi(r.URL.Query().Get("version"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid version number")
+		return
+	}
+
+	stored, ok := f.accounts[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("record %s does not exist", id))
+		return
+	}
+	if version != stored.Version {
+		writeError(w, http.StatusConflict, "invalid version")
+		return
+	}
+
+	delete(f.accounts, id)
+	for i, ordered := range f.order {
+		if ordered == id {
+			f.order = append(f.order[:i], f.order[i+1:]...)
+			break
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// accountValue returns the named top level member or attribute of account, formatted as in a filter.
+func accountValue(account form3.Account, name string) string {
+	switch name {
+	case "id":
+		return account.ID
+	case "organisation_id":
+		return account.OrganisationID
+	case "type":
+		return account.Type
+	case "version":
+		return strconv.Itoa(account.Version)
+	}
+
+	b, err := json.Marshal(account.Attributes)
+	if err != nil {
+		return ""
+	}
+	var attributes map[string]interface{}
+	if err := json.Unmarshal(b, &attributes); err != nil {
+		return ""
+	}
+
+	value, ok := attributes[name]
+	if !ok {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,274 @@
+package cassette
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+	"github.com/form3tech-oss/interview-accountapi/form3/form3test"
+)
+
+func Test_RecordAndReplay_Success(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+
+	srv := httptest.NewServer(form3test.NewFake())
+	u, _ := url.Parse(srv.URL)
+
+	rec, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runAccountCalls(t, testClient(t, u.Host, rec))
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	srv.Close()
+
+	// The server is gone; everything has to come from the cassette.
+	replay, err := New(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runAccountCalls(t, testClient(t, u.Host, replay))
+
+	if _, _, err := testClient(t, u.Host, replay).Accounts().Fetch(context.Background(), "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"); err == nil {
+		t.Error("Expected an error for a request that was not recorded")
+	}
+}
+
+func Test_Record_Scrubs_Success(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+
+	srv := httptest.NewServer(form3test.NewFake())
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+
+	rec, err := New(path, ModeRecord, ScrubHeaders("X-Team-Token"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := testClient(t, u.Host, rec)
+
+	account := testAccount()
+	account.Attributes.AccountNumber = "41426819"
+	account.Attributes.AlternativeBankAccountNames = []string{"Sam Holder"}
+	if _, _, err := client.Accounts().Create(context.Background(), account); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"41426819", "Sam Holder"} {
+		if strings.Contains(string(b), secret) {
+			t.Error("Expected", secret, "to be scrubbed, Got:", string(b))
+		}
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var payload form3.DataEnvelope[form3.Account]
+	if err := json.Unmarshal([]byte(c.Interactions[0].Request.Body), &payload); err != nil {
+		t.Fatal(err)
+	}
+	if names := payload.Data.Attributes.AlternativeBankAccountNames; len(names) != 1 || names[0] != "REDACTED" {
+		t.Error("Expected: the shape of scrubbed fields to be kept", "Got:", names)
+	}
+}
+
+func Test_RequestHash_Success(t *testing.T) {
+	recorded := Request{Method: "GET", Path: "/v1/organisation/accounts", Query: url.Values{"page[size]": {"10"}, "page[number]": {"0"}}, Body: `{"a": 1, "b": 2}`}
+
+	cases := []struct {
+		name     string
+		req      Request
+		expected bool
+	}{
+		{"same", recorded, true},
+		{"query order and body formatting", Request{Method: "GET", Path: recorded.Path, Query: url.Values{"page[number]": {"0"}, "page[size]": {"10"}}, Body: `{"b":2,"a":1}`}, true},
+		{"method", Request{Method: "POST", Path: recorded.Path, Query: recorded.Query, Body: recorded.Body}, false},
+		{"query", Request{Method: "GET", Path: recorded.Path, Query: url.Values{"page[size]": {"20"}}, Body: recorded.Body}, false},
+		{"body", Request{Method: "GET", Path: recorded.Path, Query: recorded.Query, Body: `{"a": 2}`}, false},
+	}
+
+	for _, c := range cases {
+		if got := recorded.hash(nil) == c.req.hash(nil); got != c.expected {
+			t.Error(c.name, "Expected:", c.expected, "Got:", got)
+		}
+	}
+
+	if keyed := recorded.hash([]byte("key-1")); keyed == recorded.hash(nil) || keyed == recorded.hash([]byte("key-2")) {
+		t.Error("Expected: keyed hashes to depend on the key", "Got:", keyed)
+	}
+}
+
+func Test_Record_HashesScrubbedRequest_Success(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+
+	srv := httptest.NewServer(form3test.NewFake())
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+
+	rec, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := testClient(t, u.Host, rec).Accounts().Filter("iban", "GB33BUKB20201555555555").List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := c.Interactions[0].Request
+	hash := saved.Hash
+	saved.Hash = ""
+	if hash != saved.hash(nil) {
+		t.Error("Expected: the hash of the scrubbed request", "Got:", hash)
+	}
+
+	// The IBAN cannot be confirmed from the hash
+	saved.Query = url.Values{}
+	for key, values := range c.Interactions[0].Request.Query {
+		saved.Query[key] = values
+	}
+	saved.Query.Set("filter[iban]", "GB33BUKB20201555555555")
+	if hash == saved.hash(nil) {
+		t.Error("Expected: the hash not to match the request as sent")
+	}
+}
+
+func Test_Replay_HashKey_MatchesBeforeScrubbing_Success(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+
+	fake := form3test.NewFake()
+	srv := httptest.NewServer(fake)
+	u, _ := url.Parse(srv.URL)
+
+	gb, ie := testAccount(), testAccount()
+	gb.Attributes.Iban = "GB33BUKB20201555555555"
+	ie.ID, ie.Attributes.Iban = "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", "IE64IRCE92050112345678"
+	fake.Seed(*gb, *ie)
+
+	// Only the IBAN filter differs, and it is scrubbed in the cassette
+	find := func(client *form3.Client, iban string) string {
+		accounts, _, err := client.Accounts().Filter("iban", iban).List(context.Background())
+		if err != nil || len(accounts) != 1 {
+			t.Fatal("Expected: 1 account", "Got:", accounts, err)
+		}
+		return accounts[0].ID
+	}
+
+	rec, err := New(path, ModeRecord, HashKey([]byte("not-committed")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := testClient(t, u.Host, rec)
+	find(client, gb.Attributes.Iban)
+	find(client, ie.Attributes.Iban)
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	srv.Close()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), gb.Attributes.Iban) || strings.Contains(string(b), ie.Attributes.Iban) {
+		t.Error("Expected the IBANs to be scrubbed, Got:", string(b))
+	}
+
+	replay, err := New(path, ModeReplay, HashKey([]byte("not-committed")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = testClient(t, u.Host, replay)
+	if id := find(client, ie.Attributes.Iban); id != ie.ID {
+		t.Error("Expected:", ie.ID, "Got:", id)
+	}
+	if id := find(client, gb.Attributes.Iban); id != gb.ID {
+		t.Error("Expected:", gb.ID, "Got:", id)
+	}
+}
+
+func Test_Scrub_AccountHolderFields_Success(t *testing.T) {
+	body := newScrubber().body(`{"data": [
+		{"type": "accounts", "attributes": {"name": ["Sam Holder"], "country": "GB"}},
+		{"type": "organisations", "attributes": {"name": "Payments Ltd"}}
+	]}`)
+
+	if strings.Contains(string(body), "Sam Holder") {
+		t.Error("Expected the account holder name to be scrubbed, Got:", body)
+	}
+	for _, kept := range []string{"Payments Ltd", "GB"} {
+		if !strings.Contains(string(body), kept) {
+			t.Error("Expected", kept, "to be kept, Got:", body)
+		}
+	}
+}
+
+// runAccountCalls makes the same calls when recording and replaying, so they must give the same results.
+func runAccountCalls(t *testing.T, client *form3.Client) {
+	t.Helper()
+	ctx := context.Background()
+
+	accounts, _, err := client.Accounts().List(ctx)
+	if err != nil || len(accounts) != 0 {
+		t.Fatal("Expected: no accounts", "Got:", accounts, err)
+	}
+
+	if _, _, err := client.Accounts().Create(ctx, testAccount()); err != nil {
+		t.Fatal(err)
+	}
+
+	accounts, res, err := client.Accounts().List(ctx)
+	if err != nil || len(accounts) != 1 {
+		t.Fatal("Expected: 1 account", "Got:", accounts, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Error("Expected:", http.StatusOK, "Got:", res.StatusCode)
+	}
+}
+
+func testClient(t *testing.T, host string, rec *Recorder) *form3.Client {
+	client, err := form3.NewClient(
+		form3.SetHost(host),
+		form3.SetTransport(rec),
+		form3.SetInfoLog(nil),
+		form3.SetErrorLog(nil),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func testAccount() *form3.Account {
+	return &form3.Account{
+		ID:             "158f775c-4ecd-4861-b33d-30df9a29de78",
+		OrganisationID: "358f775b-4ecd-4861-b33d-30df9a29de78",
+		Type:           "accounts",
+		Attributes: form3.AccountAttributes{
+			Country: "GB",
+			BankID:  "400300",
+		},
+	}
+}
@@ -0,0 +1,218 @@
+package cassette
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// Recorder is an http.RoundTripper that records or replays interactions, see the package documentation.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	scrubber  *scrubber
+	hashKey   []byte // see HashKey
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool // interactions already replayed
+}
+
+// RecorderOptionFunc is a function that configures a Recorder.
+type RecorderOptionFunc func(*Recorder)
+
+// SetTransport sets the transport requests are passed on to when recording (http.DefaultTransport by default).
+func SetTransport(transport http.RoundTripper) RecorderOptionFunc {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// ScrubHeaders adds headers whose values are replaced with "REDACTED" in cassettes, on top of
+// Authorization, Cookie, Set-Cookie, Signature and X-Api-Key.
+func ScrubHeaders(names ...string) RecorderOptionFunc {
+	return func(r *Recorder) {
+		for _, name := range names {
+			r.scrubber.headers[http.CanonicalHeaderKey(name)] = true
+		}
+	}
+}
+
+// ScrubFields adds JSON members whose string values are replaced with "REDACTED" in cassette bodies wherever they appear,
+// and query params and filters of the same names. Account numbers and IBANs are always scrubbed,
+// and the account holder's names, title and secondary identification in account attributes.
+func ScrubFields(names ...string) RecorderOptionFunc {
+	return func(r *Recorder) {
+		for _, name := range names {
+			r.scrubber.fields[name] = true
+		}
+	}
+}
+
+// HashKey matches requests on an HMAC of them as sent, before scrubbing, so that requests differing only in scrubbed data
+// are told apart. Without a key, requests are matched as saved, scrubbed: a plain hash of the request as sent would let
+// low-entropy values like account numbers be guessed back from the cassette. Replaying needs the key used to record,
+// which must never be committed, e.g. HashKey([]byte(os.Getenv("FORM3_CASSETTE_KEY"))). An empty key is ignored.
+func HashKey(key []byte) RecorderOptionFunc {
+	return func(r *Recorder) {
+		if len(key) > 0 {
+			r.hashKey = key
+		}
+	}
+}
+
+// New creates a Recorder for the cassette at path. In ModeReplay the cassette must exist.
+func New(path string, mode Mode, options ...RecorderOptionFunc) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		scrubber:  newScrubber(),
+		cassette:  &Cassette{},
+	}
+
+	for _, option := range options {
+		option(r)
+	}
+
+	if mode == ModeReplay {
+		c, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = c
+		r.used = make([]bool, len(c.Interactions))
+	}
+
+	return r, nil
+}
+
+// RoundTrip records or replays a request.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.Query(),
+		Header: req.Header.Clone(),
+		Body:   Body(body),
+	}
+
+	// Matched on the request as sent only with a secret key, see HashKey
+	var hash string
+	if r.hashKey != nil {
+		hash = raw.hash(r.hashKey)
+	}
+	recorded := r.scrubber.request(raw)
+	if r.hashKey == nil {
+		hash = recorded.hash(nil)
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, hash)
+	}
+	recorded.Hash = hash
+	return r.record(req, recorded)
+}
+
+// Stop saves the cassette when recording. It does nothing when replaying.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.cassette.Save(r.path)
+}
+
+func (r *Recorder) record(req *http.Request, recorded Request) (*http.Response, error) {
+	res, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: recorded,
+		Response: r.scrubber.response(Response{
+			StatusCode: res.StatusCode,
+			Header:     res.Header.Clone(),
+			Body:       Body(body),
+		}),
+	})
+
+	return res, nil
+}
+
+func (r *Recorder) replay(req *http.Request, hash string) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || interaction.Request.Hash != hash {
+			continue
+		}
+		r.used[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          ioutil.NopCloser(bytes.NewReader([]byte(interaction.Response.Body))),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("cassette %s: no recorded interaction for %s %s", r.path, req.Method, req.URL.RequestURI())
+}
+
+// hash identifies a request by its method, path, query and body, ignoring query order and body formatting.
+// It is an HMAC when key is set.
+func (req Request) hash(key []byte) string {
+	h := sha256.New()
+	if key != nil {
+		h = hmac.New(sha256.New, key)
+	}
+	fmt.Fprintf(h, "%s\n%s\n%s\n%s", req.Method, req.Path, req.Query.Encode(), normalizeBody(req.Body))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// readBody reads the body of req, leaving it readable for the transport.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,158 @@
+package cassette
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const redacted string = "REDACTED"
+
+// scrubber removes secrets and personal data from interactions before they are saved.
+type scrubber struct {
+	headers       map[string]bool
+	fields        map[string]bool // scrubbed wherever they appear
+	accountFields map[string]bool // scrubbed in the attributes of accounts only, e.g. "name", which other resources use for non-personal data
+}
+
+func newScrubber() *scrubber {
+	return &scrubber{
+		headers: map[string]bool{
+			"Authorization": true,
+			"Cookie":        true,
+			"Set-Cookie":    true,
+			"Signature":     true,
+			"X-Api-Key":     true,
+		},
+		fields: map[string]bool{
+			"account_number": true,
+			"iban":           true,
+		},
+		accountFields: map[string]bool{
+			"title":                          true,
+			"first_name":                     true,
+			"name":                           true,
+			"bank_account_name":              true,
+			"alternative_bank_account_names": true,
+			"secondary_identification":       true,
+		},
+	}
+}
+
+func (s *scrubber) request(req Request) Request {
+	req.Header = s.header(req.Header)
+	req.Query = s.query(req.Query)
+	req.Body = s.body(req.Body)
+	return req
+}
+
+func (s *scrubber) response(res Response) Response {
+	res.Header = s.header(res.Header)
+	res.Body = s.body(res.Body)
+	return res
+}
+
+func (s *scrubber) header(header http.Header) http.Header {
+	for name := range header {
+		if s.headers[http.CanonicalHeaderKey(name)] {
+			header[name] = []string{redacted}
+		}
+	}
+	return header
+}
+
+// query redacts the values of params named after scrubbed fields, and of filters on them, e.g. filter[iban].
+func (s *scrubber) query(query url.Values) url.Values {
+	for key := range query {
+		name := key
+		if strings.HasPrefix(key, "filter[") && strings.HasSuffix(key, "]") {
+			name = key[len("filter[") : len(key)-1]
+		}
+		if s.fields[name] || s.accountFields[name] {
+			for i := range query[key] {
+				query[key][i] = redacted
+			}
+		}
+	}
+	return query
+}
+
+// body redacts the scrubbed fields of a JSON body. Bodies that are not JSON are kept as they are.
+func (s *scrubber) body(body Body) Body {
+	var v interface{}
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		return body
+	}
+
+	b, err := json.Marshal(s.value(v, false))
+	if err != nil {
+		return body
+	}
+	return Body(b)
+}
+
+// value redacts the strings in v, or in the members of v with scrubbed names, keeping its shape
+// so redacted bodies still decode into the models.
+func (s *scrubber) value(v interface{}, scrub bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for name, member := range v {
+			v[name] = s.value(member, scrub || s.fields[name])
+		}
+		// The account holder's details
+		if attributes, ok := v["attributes"].(map[string]interface{}); ok && v["type"] == "accounts" {
+			for name, member := range attributes {
+				if s.accountFields[name] {
+					attributes[name] = s.value(member, true)
+				}
+			}
+		}
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = s.value(elem, scrub)
+		}
+	case string:
+		if scrub {
+			return redacted
+		}
+		return s.link(v)
+	}
+	return v
+}
+
+// link scrubs the query of a string that is a link, e.g. the pagination links of a list filtered by filter[iban].
+func (s *scrubber) link(v string) string {
+	if !strings.Contains(v, "?") {
+		return v
+	}
+	u, err := url.Parse(v)
+	if err != nil || u.RawQuery == "" {
+		return v
+	}
+
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return v
+	}
+	original := query.Encode()
+	if scrubbed := s.query(query).Encode(); scrubbed != original {
+		u.RawQuery = scrubbed
+		return u.String()
+	}
+	return v
+}
+
+// normalizeBody returns a JSON body with its formatting and member order normalized, so equal bodies compare equal.
+func normalizeBody(body Body) string {
+	var v interface{}
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		return string(body)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return string(body)
+	}
+	return string(b)
+}
@@ -0,0 +1,293 @@
+// Package chaos injects faults into requests made by a form3.Client, to test that callers survive Form3 outages.
+//
+//	client, err := form3.NewClient(
+//		chaos.Inject(42,
+//			chaos.Latency(300*time.Millisecond).WithProbability(0.5),
+//			chaos.Status(http.StatusServiceUnavailable).OnPath("/v1/organisation/accounts/*").WithProbability(0.2),
+//			chaos.ConnectionReset().OnMethod("POST").WithProbability(0.1),
+//		),
+//	)
+//
+// Faults are rolled in order for every request they match. Latency is added on top of other faults;
+// of the other faults, the first one rolled is injected. The same seed injects the same faults into the same sequence of requests.
+package chaos
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+)
+
+// Kind is a kind of fault.
+type Kind int
+
+// Kinds of faults.
+const (
+	KindLatency Kind = iota
+	KindConnectionReset
+	KindTimeout
+	KindTruncatedBody
+	KindMalformedJSON
+	KindStatus
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindLatency:
+		return "latency"
+	case KindConnectionReset:
+		return "connection reset"
+	case KindTimeout:
+		return "timeout"
+	case KindTruncatedBody:
+		return "truncated body"
+	case KindMalformedJSON:
+		return "malformed JSON"
+	case KindStatus:
+		return "status"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Fault is a fault injected into matching requests. Create one with Latency, ConnectionReset, Timeout,
+// TruncatedBody, MalformedJSON or Status; by default it is injected into every request.
+type Fault struct {
+	kind        Kind
+	duration    time.Duration // for latency and timeouts
+	status      int
+	probability float64
+	method      string
+	path        string
+}
+
+// Latency delays requests by d before they are sent.
+func Latency(d time.Duration) *Fault {
+	return &Fault{kind: KindLatency, duration: d, probability: 1}
+}
+
+// ConnectionReset fails requests with ECONNRESET without sending them.
+func ConnectionReset() *Fault {
+	return &Fault{kind: KindConnectionReset, probability: 1}
+}
+
+// Timeout makes requests hang for d, or until their context is done, then fail with a timeout error (a net.Error).
+func Timeout(d time.Duration) *Fault {
+	return &Fault{kind: KindTimeout, duration: d, probability: 1}
+}
+
+// TruncatedBody cuts response bodies in half, failing reads past that with io.ErrUnexpectedEOF.
+func TruncatedBody() *Fault {
+	return &Fault{kind: KindTruncatedBody, probability: 1}
+}
+
+// MalformedJSON replaces response bodies with ones that are not valid JSON, as some proxies do.
+func MalformedJSON() *Fault {
+	return &Fault{kind: KindMalformedJSON, probability: 1}
+}
+
+// Status answers requests with status, in the format of Form3 errors, without sending them.
+func Status(status int) *Fault {
+	return &Fault{kind: KindStatus, status: status, probability: 1}
+}
+
+// WithProbability injects the fault into a fraction, between 0 and 1, of the requests it matches.
+func (f *Fault) WithProbability(probability float64) *Fault {
+	f.probability = probability
+	return f
+}
+
+// OnMethod only injects the fault into requests with method, e.g. "POST".
+func (f *Fault) OnMethod(method string) *Fault {
+	f.method = method
+	return f
+}
+
+// OnPath only injects the fault into requests whose path matches pattern, in the syntax of path.Match,
+// e.g. "/v1/organisation/accounts/*".
+func (f *Fault) OnPath(pattern string) *Fault {
+	f.path = pattern
+	return f
+}
+
+// Kind returns the kind of the fault.
+func (f *Fault) Kind() Kind {
+	return f.kind
+}
+
+func (f *Fault) matches(req *http.Request) bool {
+	if f.method != "" && !strings.EqualFold(f.method, req.Method) {
+		return false
+	}
+	if f.path != "" {
+		if ok, _ := path.Match(f.path, req.URL.Path); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Inject returns a client option injecting faults into the requests of the client, rolling them with seed.
+// Faults are injected around the final transport of the client (see form3.WrapTransport), so Inject can be passed
+// before or after SetTransport, SetHTTPClient and the transport options.
+func Inject(seed int64, faults ...*Fault) form3.ClientOptionFunc {
+	return form3.WrapTransport(func(next http.RoundTripper) http.RoundTripper {
+		return New(next, seed, faults...)
+	})
+}
+
+// Transport is an http.RoundTripper injecting faults into requests before passing them on.
+type Transport struct {
+	next   http.RoundTripper
+	faults []*Fault
+
+	mu       sync.Mutex
+	rand     *rand.Rand
+	injected []Kind
+}
+
+// New creates a Transport injecting faults into requests passed on to next, rolling them with seed.
+func New(next http.RoundTripper, seed int64, faults ...*Fault) *Transport {
+	return &Transport{
+		next:   next,
+		faults: faults,
+		rand:   rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Injected returns the kinds of the faults injected so far, in order.
+func (t *Transport) Injected() []Kind {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]Kind(nil), t.injected...)
+}
+
+// RoundTrip injects the faults rolled for req.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	latency, fault := t.roll(req)
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	if fault == nil {
+		return t.next.RoundTrip(req)
+	}
+
+	switch fault.kind {
+	case KindConnectionReset:
+		return nil, fmt.Errorf("chaos: %s %s: %w", req.Method, req.URL.Path, syscall.ECONNRESET)
+
+	case KindTimeout:
+		select {
+		case <-time.After(fault.duration):
+			return nil, &timeoutError{fmt.Sprintf("chaos: %s %s: timeout after %s", req.Method, req.URL.Path, fault.duration)}
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+
+	case KindStatus:
+		body := fmt.Sprintf(`{"error_message": "chaos: injected %d"}`, fault.status)
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", fault.status, http.StatusText(fault.status)),
+			StatusCode:    fault.status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": {"application/json"}},
+			Body:          ioutil.NopCloser(strings.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	switch fault.kind {
+	case KindTruncatedBody:
+		res.Body = &truncatedBody{Reader: bytes.NewReader(body[:len(body)/2])}
+	case KindMalformedJSON:
+		res.Body = ioutil.NopCloser(bytes.NewReader(append([]byte("<!-- chaos -->"), body...)))
+	}
+	res.ContentLength = -1
+	res.Header.Del("Content-Length")
+
+	return res, nil
+}
+
+// roll returns the latency to add to req and the other fault, if any, to inject into it.
+func (t *Transport) roll(req *http.Request) (time.Duration, *Fault) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var latency time.Duration
+	var injected *Fault
+	for _, f := range t.faults {
+		if !f.matches(req) {
+			continue
+		}
+		// Roll every matching fault, so the sequence of rolls only depends on the requests.
+		if t.rand.Float64() >= f.probability {
+			continue
+		}
+
+		switch {
+		case f.kind == KindLatency:
+			latency += f.duration
+			t.injected = append(t.injected, f.kind)
+		case injected == nil:
+			injected = f
+			t.injected = append(t.injected, f.kind)
+		}
+	}
+	return latency, injected
+}
+
+// truncatedBody fails reads past the end of its reader, as when a connection drops mid-response.
+type truncatedBody struct {
+	io.Reader
+}
+
+func (b *truncatedBody) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (b *truncatedBody) Close() error {
+	return nil
+}
+
+// timeoutError is a net.Error reporting a timeout.
+type timeoutError struct {
+	msg string
+}
+
+func (e *timeoutError) Error() string   { return e.msg }
+func (e *timeoutError) Timeout() bool   { return true }
+func (e *timeoutError) Temporary() bool { return true }
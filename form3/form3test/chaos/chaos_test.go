@@ -0,0 +1,112 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+	"github.com/form3tech-oss/interview-accountapi/form3/form3test"
+)
+
+const accountID string = "158f775c-4ecd-4861-b33d-30df9a29de78"
+
+func Test_Status_OnPath_Success(t *testing.T) {
+	client := testClient(t, Inject(1, Status(http.StatusServiceUnavailable).OnPath("/v1/organisation/accounts/*")))
+
+	_, res, err := client.Accounts().Fetch(context.Background(), accountID)
+	if err == nil || res.StatusCode != http.StatusServiceUnavailable {
+		t.Error("Expected:", http.StatusServiceUnavailable, "Got:", res, err)
+	}
+
+	// Lists do not match the path.
+	if _, _, err := client.Accounts().List(context.Background()); err != nil {
+		t.Error(err)
+	}
+}
+
+func Test_Inject_KeptBySetHTTPClient_Success(t *testing.T) {
+	client := testClient(t, Inject(1, Status(http.StatusServiceUnavailable)), form3.SetHTTPClient(&http.Client{}))
+
+	_, res, err := client.Accounts().Fetch(context.Background(), accountID)
+	if err == nil || res.StatusCode != http.StatusServiceUnavailable {
+		t.Error("Expected:", http.StatusServiceUnavailable, "Got:", res, err)
+	}
+}
+
+func Test_ConnectionReset_Failure(t *testing.T) {
+	client := testClient(t, Inject(1, ConnectionReset().OnMethod("GET")))
+
+	_, _, err := client.Accounts().Fetch(context.Background(), accountID)
+	if !errors.Is(err, syscall.ECONNRESET) {
+		t.Error("Expected:", syscall.ECONNRESET, "Got:", err)
+	}
+}
+
+func Test_Timeout_Failure(t *testing.T) {
+	client := testClient(t, Inject(1, Timeout(10*time.Millisecond)))
+
+	_, _, err := client.Accounts().Fetch(context.Background(), accountID)
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Error("Expected: a timeout", "Got:", err)
+	}
+}
+
+func Test_Latency_ContextDeadline_Failure(t *testing.T) {
+	client := testClient(t, Inject(1, Latency(time.Second)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, _, err := client.Accounts().Fetch(ctx, accountID)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("Expected:", context.DeadlineExceeded, "Got:", err)
+	}
+}
+
+func Test_BrokenBodies_Failure(t *testing.T) {
+	for _, fault := range []*Fault{TruncatedBody(), MalformedJSON()} {
+		client := testClient(t, Inject(1, fault))
+
+		if _, _, err := client.Accounts().Fetch(context.Background(), accountID); err == nil {
+			t.Error(fault.Kind(), "Expected: a decoding error", "Got: nil")
+		}
+	}
+}
+
+func Test_Probability_Deterministic(t *testing.T) {
+	run := func() []Kind {
+		transport := New(http.DefaultTransport, 42, Status(http.StatusInternalServerError).WithProbability(0.5))
+		client := testClient(t, form3.SetTransport(transport))
+
+		for i := 0; i < 20; i++ {
+			client.Accounts().Fetch(context.Background(), accountID)
+		}
+		return transport.Injected()
+	}
+
+	first, second := run(), run()
+	if !reflect.DeepEqual(first, second) {
+		t.Error("Expected the same faults for the same seed, Got:", first, second)
+	}
+	if len(first) == 0 || len(first) == 20 {
+		t.Error("Expected: some requests to fail", "Got:", len(first))
+	}
+}
+
+func testClient(t *testing.T, options ...form3.ClientOptionFunc) *form3.Client {
+	fake := form3test.NewFake()
+	fake.Seed(form3.Account{
+		ID:             accountID,
+		OrganisationID: "358f775b-4ecd-4861-b33d-30df9a29de78",
+		Type:           "accounts",
+		Attributes:     form3.AccountAttributes{Country: "GB"},
+	})
+	return form3test.NewClientWithFake(t, fake, options...)
+}
@@ -0,0 +1,160 @@
+package factory
+
+import (
+	"fmt"
+	"strconv"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+)
+
+// AccountBuilder builds accounts valid for their country. Overrides are applied after the generated values,
+// whatever the order they were set in.
+type AccountBuilder struct {
+	factory   *Factory
+	country   string
+	overrides []func(*form3.Account)
+}
+
+// Country sets the country of the account, which decides the formats of its bank details.
+func (b *AccountBuilder) Country(country string) *AccountBuilder {
+	b.country = country
+	return b
+}
+
+// ID overrides the generated UUID.
+func (b *AccountBuilder) ID(id string) *AccountBuilder {
+	return b.With(func(a *form3.Account) { a.ID = id })
+}
+
+// OrganisationID overrides the organisation of the factory.
+func (b *AccountBuilder) OrganisationID(organisationID string) *AccountBuilder {
+	return b.With(func(a *form3.Account) { a.OrganisationID = organisationID })
+}
+
+// BankID overrides the generated bank ID, e.g. the sort code of a GB account.
+func (b *AccountBuilder) BankID(bankID string) *AccountBuilder {
+	return b.With(func(a *form3.Account) { a.Attributes.BankID = bankID })
+}
+
+// Bic overrides the generated BIC.
+func (b *AccountBuilder) Bic(bic string) *AccountBuilder {
+	return b.With(func(a *form3.Account) { a.Attributes.Bic = bic })
+}
+
+// AccountNumber overrides the generated account number. The IBAN is not recomputed.
+func (b *AccountBuilder) AccountNumber(accountNumber string) *AccountBuilder {
+	return b.With(func(a *form3.Account) { a.Attributes.AccountNumber = accountNumber })
+}
+
+// Iban overrides the generated IBAN.
+func (b *AccountBuilder) Iban(iban string) *AccountBuilder {
+	return b.With(func(a *form3.Account) { a.Attributes.Iban = iban })
+}
+
+// BankAccountName overrides the generated account holder name.
+func (b *AccountBuilder) BankAccountName(name string) *AccountBuilder {
+	return b.With(func(a *form3.Account) { a.Attributes.BankAccountName = name })
+}
+
+// Version sets the version of the account (0 by default).
+func (b *AccountBuilder) Version(version int) *AccountBuilder {
+	return b.With(func(a *form3.Account) { a.Version = version })
+}
+
+// With adds an override of any field, e.g. With(func(a *form3.Account) { a.Attributes.JointAccount = true }).
+func (b *AccountBuilder) With(override func(*form3.Account)) *AccountBuilder {
+	b.overrides = append(b.overrides, override)
+	return b
+}
+
+// Build builds an account, with a new UUID and bank details every time.
+func (b *AccountBuilder) Build() form3.Account {
+	f := b.factory
+
+	f.mu.Lock()
+	organisationID := f.organisationID
+	f.mu.Unlock()
+
+	first, last := f.pick(firstNames), f.pick(lastNames)
+	account := form3.Account{
+		ID:             f.UUID(),
+		OrganisationID: organisationID,
+		Type:           "accounts",
+		Attributes: form3.AccountAttributes{
+			Country:               b.country,
+			FirstName:             first,
+			BankAccountName:       fmt.Sprintf("%s %s", first, last),
+			AccountClassification: "Personal",
+		},
+	}
+	b.bankDetails(&account.Attributes)
+
+	for _, override := range b.overrides {
+		override(&account)
+	}
+	return account
+}
+
+// BuildN builds n accounts, e.g. to seed a form3test.Fake: fake.Seed(f.Account().BuildN(20)...).
+func (b *AccountBuilder) BuildN(n int) []form3.Account {
+	accounts := make([]form3.Account, n)
+	for i := range accounts {
+		accounts[i] = b.Build()
+	}
+	return accounts
+}
+
+// bankDetails generates consistent bank details in the formats of the country of attributes.
+func (b *AccountBuilder) bankDetails(attributes *form3.AccountAttributes) {
+	f := b.factory
+	country := attributes.Country
+
+	switch country {
+	case "GB", "IE":
+		// Sort code and 8 digit account number; the IBAN bank code is the institution code of the BIC.
+		attributes.BaseCurrency = string(form3.GBP)
+		attributes.BankIDCode = "GBDSC"
+		if country == "IE" {
+			attributes.BaseCurrency = string(form3.EUR)
+			attributes.BankIDCode = "IENCC"
+		}
+		attributes.BankID = f.chars(digits, 6)
+		attributes.AccountNumber = f.chars(digits, 8)
+		attributes.Bic = f.Bic(country)
+		attributes.Iban = IBAN(country, attributes.Bic[:4]+attributes.BankID+attributes.AccountNumber)
+
+	case "DE":
+		// Bankleitzahl and 10 digit account number.
+		attributes.BaseCurrency = string(form3.EUR)
+		attributes.BankIDCode = "DEBLZ"
+		attributes.BankID = f.chars(digits, 8)
+		attributes.AccountNumber = f.chars(digits, 10)
+		attributes.Bic = f.Bic(country)
+		attributes.Iban = IBAN(country, attributes.BankID+attributes.AccountNumber)
+
+	case "FR":
+		// 5 digit bank and branch codes, 11 digit account number and the RIB key.
+		attributes.BaseCurrency = string(form3.EUR)
+		attributes.BankIDCode = "FR"
+		attributes.BankID = f.chars(digits, 10)
+		attributes.AccountNumber = f.chars(digits, 11)
+		attributes.Bic = f.Bic(country)
+		attributes.Iban = IBAN(country, attributes.BankID+attributes.AccountNumber+ribKey(attributes.BankID, attributes.AccountNumber))
+
+	case "NL":
+		// No bank ID; the IBAN bank code is the institution code of the BIC.
+		attributes.BaseCurrency = string(form3.EUR)
+		attributes.AccountNumber = f.chars(digits, 10)
+		attributes.Bic = f.Bic(country)
+		attributes.Iban = IBAN(country, attributes.Bic[:4]+attributes.AccountNumber)
+	}
+}
+
+// ribKey returns the 2 check digits of a French RIB, from its bank and branch codes (bankID) and numeric account number.
+func ribKey(bankID, accountNumber string) string {
+	bank, _ := strconv.ParseInt(bankID[:5], 10, 64)
+	branch, _ := strconv.ParseInt(bankID[5:], 10, 64)
+	account, _ := strconv.ParseInt(accountNumber, 10, 64)
+
+	return fmt.Sprintf("%02d", 97-(89*bank+15*branch+3*account)%97)
+}
@@ -0,0 +1,100 @@
+package factory
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+	"github.com/form3tech-oss/interview-accountapi/form3/form3test"
+)
+
+func Test_IBAN_Success(t *testing.T) {
+	// Examples from the IBAN registry.
+	cases := []struct {
+		country, bban, iban string
+	}{
+		{"GB", "NWBK60161331926819", "GB29NWBK60161331926819"},
+		{"DE", "370400440532013000", "DE89370400440532013000"},
+		{"FR", "20041010050500013M02606", "FR1420041010050500013M02606"},
+		{"NL", "ABNA0417164300", "NL91ABNA0417164300"},
+	}
+
+	for _, c := range cases {
+		if got := IBAN(c.country, c.bban); got != c.iban {
+			t.Error("Expected:", c.iban, "Got:", got)
+		}
+		if !ValidIBAN(c.iban) {
+			t.Error("Expected:", c.iban, "to be valid")
+		}
+	}
+
+	if ValidIBAN("GB28NWBK60161331926819") {
+		t.Error("Expected: GB28NWBK60161331926819 to be invalid")
+	}
+}
+
+func Test_RibKey_Success(t *testing.T) {
+	// FR76 3000 6000 0112 3456 7890 189: bank 30006, branch 00001, account 12345678901, key 89.
+	if key := ribKey("3000600001", "12345678901"); key != "89" {
+		t.Error("Expected: 89", "Got:", key)
+	}
+}
+
+func Test_BuildAccount_ValidForFake_Success(t *testing.T) {
+	f := New()
+	client := form3test.NewClient(t)
+
+	for _, country := range []string{"GB", "IE", "DE", "FR", "NL"} {
+		account := f.Account().Country(country).Build()
+
+		if !ValidIBAN(account.Attributes.Iban) {
+			t.Error(country, "Expected a valid IBAN, Got:", account.Attributes.Iban)
+		}
+
+		_, res, err := client.Accounts().Create(context.Background(), &account)
+		if err != nil || res.StatusCode != http.StatusCreated {
+			raw, _ := form3.RawJSON(res)
+			t.Error(country, "Expected:", http.StatusCreated, "Got:", err, string(raw))
+		}
+	}
+}
+
+func Test_BuildAccount_Seeded_Deterministic(t *testing.T) {
+	first := NewSeeded(42).Account().Country("DE").BuildN(3)
+	second := NewSeeded(42).Account().Country("DE").BuildN(3)
+
+	if !reflect.DeepEqual(first, second) {
+		t.Error("Expected the same accounts for the same seed, Got:", first, second)
+	}
+	if first[0].ID == first[1].ID {
+		t.Error("Expected unique IDs, Got:", first[0].ID, first[1].ID)
+	}
+}
+
+func Test_BuildAccount_Overrides_Success(t *testing.T) {
+	account := NewSeeded(1).Account().
+		With(func(a *form3.Account) { a.Attributes.JointAccount = true }).
+		BankID("400300").
+		Country("GB").
+		Build()
+
+	if account.Attributes.BankID != "400300" || !account.Attributes.JointAccount {
+		t.Error("Expected the overrides to be applied, Got:", account.Attributes)
+	}
+}
+
+func Test_BuildN_SeedsFake_Success(t *testing.T) {
+	fake := form3test.NewFake()
+	fake.Seed(NewSeeded(7).Account().BuildN(12)...)
+
+	client := form3test.NewClientWithFake(t, fake)
+	accounts, _, err := client.Accounts().Size(5).ListAll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 12 {
+		t.Error("Expected: 12 accounts", "Got:", len(accounts))
+	}
+}
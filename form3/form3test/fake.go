@@ -0,0 +1,194 @@
+package form3test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+)
+
+const (
+	accountsPath string = "/v1/organisation/accounts"
+
+	defaultPageSize int = 100
+	maxPageSize     int = 100
+)
+
+// Fake is an in-memory implementation of the Form3 accounts API. It also serves bank IDs, BICs, claims, limits,
+// organisations, roles, subscriptions and users, stored as they are sent without validating their attributes.
+// It is an http.Handler; serve it with httptest or use NewClientWithFake. A Fake is safe for concurrent use.
+type Fake struct {
+	mu        sync.Mutex
+	accounts  map[string]form3.Account
+	order     []string // account IDs in creation order, the order they are listed in
+	resources map[string]*resources
+}
+
+// NewFake creates an empty Fake.
+func NewFake() *Fake {
+	return &Fake{
+		accounts:  map[string]form3.Account{},
+		resources: newResourceStores(),
+	}
+}
+
+func newResourceStores() map[string]*resources {
+	stores := make(map[string]*resources, len(resourcePaths))
+	for resourceType, path := range resourcePaths {
+		stores[resourceType] = newResources(resourceType, path)
+	}
+	return stores
+}
+
+// Seed stores accounts as if they had been created, replacing any with the same ID. They are not validated.
+func (f *Fake) Seed(accounts ...form3.Account) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, account := range accounts {
+		if _, ok := f.accounts[account.ID]; !ok {
+			f.order = append(f.order, account.ID)
+		}
+		f.accounts[account.ID] = account
+	}
+}
+
+// Accounts returns the stored accounts in creation order.
+func (f *Fake) Accounts() []form3.Account {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	accounts := make([]form3.Account, 0, len(f.order))
+	for _, id := range f.order {
+		accounts = append(accounts, f.accounts[id])
+	}
+	return accounts
+}
+
+// SeedResources stores resources of resourceType, e.g. "subscriptions", as if they had been created,
+// replacing any with the same ID. Each resource is converted with NewResource.
+func (f *Fake) SeedResources(resourceType string, resources ...interface{}) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	store, ok := f.resources[resourceType]
+	if !ok {
+		return fmt.Errorf("the fake does not serve %q resources", resourceType)
+	}
+
+	for _, v := range resources {
+		resource, err := NewResource(v)
+		if err != nil {
+			return err
+		}
+		store.seed(resource)
+	}
+	return nil
+}
+
+// Resources returns the stored resources of resourceType in creation order.
+// Decode them into their form3 type with json, or compare them with NewResource.
+func (f *Fake) Resources(resourceType string) []Resource {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	store, ok := f.resources[resourceType]
+	if !ok {
+		return nil
+	}
+	return store.all()
+}
+
+// Reset removes every account and resource.
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.accounts = map[string]form3.Account{}
+	f.order = nil
+	f.resources = newResourceStores()
+}
+
+// ServeHTTP implements the accounts endpoints:
+//
+//	GET    /v1/organisation/accounts?page[number]=&page[size]=&filter[{attribute}]=
+//	POST   /v1/organisation/accounts
+//	GET    /v1/organisation/accounts/{account_id}
+//	PATCH  /v1/organisation/accounts/{account_id}
+//	DELETE /v1/organisation/accounts/{account_id}?version=
+//
+// and the same endpoints for the other resources, e.g. /v1/notification/subscriptions and /v1/organisation/units.
+func (f *Fake) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, store := range f.resources {
+		if r.URL.Path == store.path || strings.HasPrefix(r.URL.Path, store.path+"/") {
+			store.serve(w, r)
+			return
+		}
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, accountsPath)
+	switch {
+	case id == "" || id == "/":
+		switch r.Method {
+		case http.MethodGet:
+			f.list(w, r)
+		case http.MethodPost:
+			f.create(w, r)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+
+	case id != r.URL.Path && !strings.Contains(id[1:], "/"):
+		id = id[1:]
+		if !isUUID(id) {
+			writeError(w, http.StatusBadRequest, "id is not a valid uuid")
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			f.fetch(w, id)
+		case http.MethodPatch:
+			f.update(w, r, id)
+		case http.MethodDelete:
+			f.delete(w, r, id)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no route for %s", r.URL.Path))
+	}
+}
+
+// errorResponse is the body of error responses.
+type errorResponse struct {
+	ErrorMessage string `json:"error_message"`
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{ErrorMessage: message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/vnd.api+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// sortedKeys returns the keys of m in order, so responses do not depend on map iteration.
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
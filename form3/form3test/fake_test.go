@@ -0,0 +1,274 @@
+package form3test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+)
+
+func Test_AccountLifecycle_Success(t *testing.T) {
+	client := NewClient(t)
+	ctx := context.Background()
+
+	account, res, err := client.Accounts().Create(ctx, testAccount("158f775c-4ecd-4861-b33d-30df9a29de78", "GB"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if http.StatusCreated != res.StatusCode {
+		t.Error("Expected:", http.StatusCreated, "Got:", res.StatusCode)
+	}
+
+	_, res, err = client.Accounts().Create(ctx, testAccount("158f775c-4ecd-4861-b33d-30df9a29de78", "GB"))
+	if err == nil || http.StatusConflict != res.StatusCode {
+		t.Error("Expected:", http.StatusConflict, "Got:", res.StatusCode)
+	}
+
+	account.Attributes.BankID = "400302"
+	updated, _, err := client.Accounts().Update(ctx, account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Version != 1 || updated.Attributes.BankID != "400302" {
+		t.Error("Expected: version 1 with bank_id 400302", "Got:", updated.Version, updated.Attributes.BankID)
+	}
+
+	// account still carries version 0
+	_, res, err = client.Accounts().Update(ctx, account)
+	if err == nil || http.StatusConflict != res.StatusCode {
+		t.Error("Expected:", http.StatusConflict, "Got:", res.StatusCode)
+	}
+
+	ok, res, err := client.Accounts().Delete(ctx, account.ID, 0)
+	if ok || http.StatusConflict != res.StatusCode {
+		t.Error("Expected:", http.StatusConflict, "Got:", res.StatusCode)
+	}
+
+	ok, res, err = client.Accounts().Delete(ctx, account.ID, 1)
+	if err != nil || !ok {
+		t.Fatal("Expected the account to be deleted, Got:", err)
+	}
+	if http.StatusNoContent != res.StatusCode {
+		t.Error("Expected:", http.StatusNoContent, "Got:", res.StatusCode)
+	}
+
+	_, res, err = client.Accounts().Fetch(ctx, account.ID)
+	if err == nil || http.StatusNotFound != res.StatusCode {
+		t.Error("Expected:", http.StatusNotFound, "Got:", res.StatusCode)
+	}
+}
+
+func Test_CreateAccount_Validation_Failure(t *testing.T) {
+	client := NewClient(t)
+
+	account := testAccount("not-a-uuid", "gb")
+	account.Attributes.Bic = "NWBK"
+
+	_, res, err := client.Accounts().Create(context.Background(), account)
+	if err == nil || http.StatusBadRequest != res.StatusCode {
+		t.Fatal("Expected:", http.StatusBadRequest, "Got:", res.StatusCode)
+	}
+
+	raw, _ := form3.RawJSON(res)
+	for _, failure := range []string{"id in body must be of type uuid", "country in body should match", "bic in body should match"} {
+		if !strings.Contains(string(raw), failure) {
+			t.Error("Expected:", failure, "Got:", string(raw))
+		}
+	}
+}
+
+func Test_ListAccounts_Pagination_Success(t *testing.T) {
+	fake := NewFake()
+	for i := 0; i < 5; i++ {
+		fake.Seed(*testAccount(fmt.Sprintf("158f775c-4ecd-4861-b33d-30df9a29de7%d", i), "GB"))
+	}
+	client := NewClientWithFake(t, fake)
+
+	accounts, res, err := client.Accounts().Number(1).Size(2).List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 2 || accounts[0].ID != "158f775c-4ecd-4861-b33d-30df9a29de72" {
+		t.Error("Expected: accounts 2 and 3", "Got:", accounts)
+	}
+
+	var page form3.ListEnvelope[form3.Account]
+	if err := client.Decode(res, &page); err != nil {
+		t.Fatal(err)
+	}
+	if page.Links.Next == nil || !strings.Contains(*page.Links.Next, "page%5Bnumber%5D=2") {
+		t.Error("Expected: a link to page 2", "Got:", page.Links.Next)
+	}
+	if page.Links.Last == nil || !strings.Contains(*page.Links.Last, "page%5Bnumber%5D=2") {
+		t.Error("Expected: the last page to be 2", "Got:", page.Links.Last)
+	}
+
+	all, _, err := client.Accounts().Size(2).ListAll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 5 {
+		t.Error("Expected: 5 accounts", "Got:", len(all))
+	}
+}
+
+func Test_ListAllAccounts_SizeAboveCap_Success(t *testing.T) {
+	fake := NewFake()
+	for i := 0; i < 150; i++ {
+		fake.Seed(*testAccount(fmt.Sprintf("158f775c-4ecd-4861-b33d-30df9a29%04d", i), "GB"))
+	}
+	client := NewClientWithFake(t, fake)
+
+	// Pages are capped at 100 accounts, shorter than the size asked for
+	all, _, err := client.Accounts().Size(200).ListAll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 150 {
+		t.Error("Expected: 150 accounts", "Got:", len(all))
+	}
+}
+
+func Test_ListAccounts_Filter_Success(t *testing.T) {
+	fake := NewFake()
+	fake.Seed(
+		*testAccount("158f775c-4ecd-4861-b33d-30df9a29de70", "GB"),
+		*testAccount("158f775c-4ecd-4861-b33d-30df9a29de71", "FR"),
+		*testAccount("158f775c-4ecd-4861-b33d-30df9a29de72", "DE"),
+	)
+	client := NewClientWithFake(t, fake)
+
+	accounts, _, err := client.Accounts().Filter("country", "GB", "FR").List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 2 {
+		t.Error("Expected: 2 accounts", "Got:", len(accounts))
+	}
+}
+
+func Test_Reset_Success(t *testing.T) {
+	fake := NewFake()
+	fake.Seed(*testAccount("158f775c-4ecd-4861-b33d-30df9a29de78", "GB"))
+	fake.Reset()
+
+	if len(fake.Accounts()) != 0 {
+		t.Error("Expected: no accounts", "Got:", fake.Accounts())
+	}
+}
+
+func Test_Snapshot_RoundTrip_Success(t *testing.T) {
+	fake := NewFake()
+	fake.Seed(*testAccount("158f775c-4ecd-4861-b33d-30df9a29de78", "GB"))
+	if err := fake.SeedResources("limits", form3.Limit{ID: "7ab4a2f0-1c2d-4e5f-8a9b-0c1d2e3f4a5b", Type: "limits", Attributes: form3.LimitAttributes{Scheme: "FPS"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSnapshot(&buf, fake.Snapshot()); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot, err := ReadSnapshot(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored := NewFake()
+	restored.Restore(snapshot)
+	if accounts := restored.Accounts(); len(accounts) != 1 || accounts[0].Attributes.Country != "GB" {
+		t.Error("Expected: the seeded account", "Got:", accounts)
+	}
+	if limits := restored.Resources("limits"); len(limits) != 1 || limits[0].value("scheme") != "FPS" {
+		t.Error("Expected: the seeded limit", "Got:", limits)
+	}
+}
+
+func Test_SubscriptionLifecycle_Success(t *testing.T) {
+	fake := NewFake()
+	client := NewClientWithFake(t, fake)
+	ctx := context.Background()
+
+	subscription, res, err := client.Subscriptions().Create(ctx, &form3.Subscription{
+		ID:             "5e2ccb7f-5f5b-4b3e-9a1b-6d0e4f6c1a10",
+		OrganisationID: "358f775b-4ecd-4861-b33d-30df9a29de78",
+		Type:           "subscriptions",
+		Attributes: form3.SubscriptionAttributes{
+			CallbackURI:       "https://example.com/form3/events",
+			CallbackTransport: form3.CallbackTransportHTTP,
+			RecordType:        "accounts",
+			EventType:         "created",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if http.StatusCreated != res.StatusCode {
+		t.Error("Expected:", http.StatusCreated, "Got:", res.StatusCode)
+	}
+
+	subscription.Attributes.EventType = "updated"
+	updated, _, err := client.Subscriptions().Update(ctx, subscription)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Version != 1 || updated.Attributes.EventType != "updated" {
+		t.Error("Expected: version 1 with event_type updated", "Got:", updated.Version, updated.Attributes.EventType)
+	}
+
+	// subscription still carries version 0
+	if _, res, _ := client.Subscriptions().Update(ctx, subscription); http.StatusConflict != res.StatusCode {
+		t.Error("Expected:", http.StatusConflict, "Got:", res.StatusCode)
+	}
+
+	if ok, _, err := client.Subscriptions().Delete(ctx, subscription.ID, 1); err != nil || !ok {
+		t.Fatal("Expected the subscription to be deleted, Got:", err)
+	}
+	if subscriptions := fake.Resources("subscriptions"); len(subscriptions) != 0 {
+		t.Error("Expected: no subscriptions", "Got:", subscriptions)
+	}
+}
+
+func Test_Organisations_Walk_Success(t *testing.T) {
+	fake := NewFake()
+	err := fake.SeedResources("organisations",
+		form3.Organisation{ID: "88dd4407-d170-44cd-b493-881edee7029c", Type: "organisations", Attributes: form3.OrganisationAttributes{Name: "root"}},
+		form3.Organisation{ID: "0e5bf2a4-9a0c-4f6b-8c0e-3f1d2b7a9c11", OrganisationID: "88dd4407-d170-44cd-b493-881edee7029c", Type: "organisations", Attributes: form3.OrganisationAttributes{Name: "unit"}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := NewClientWithFake(t, fake)
+
+	var names []string
+	err = client.Organisations().Walk(context.Background(), "88dd4407-d170-44cd-b493-881edee7029c", func(org *form3.Organisation, depth int) error {
+		names = append(names, fmt.Sprint(depth, org.Attributes.Name))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "[0root 1unit]"; fmt.Sprint(names) != expected {
+		t.Error("Expected:", expected, "Got:", names)
+	}
+
+	if err := fake.SeedResources("payments", struct{}{}); err == nil {
+		t.Error("Expected: error for a resource type the fake does not serve", "Got: nil")
+	}
+}
+
+func testAccount(id, country string) *form3.Account {
+	return &form3.Account{
+		ID:             id,
+		OrganisationID: "358f775b-4ecd-4861-b33d-30df9a29de78",
+		Type:           "accounts",
+		Attributes: form3.AccountAttributes{
+			Country: country,
+			BankID:  "400300",
+		},
+	}
+}
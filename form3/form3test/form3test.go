@@ -0,0 +1,56 @@
+// Package form3test provides an in-memory fake of the Form3 accounts API for unit tests.
+//
+// The fake behaves like the real API: accounts are versioned, creating a duplicate or using a stale version
+// is a 409 Conflict, unknown accounts are 404s, lists are paginated with links and can be filtered,
+// and invalid accounts are rejected with a validation failure list.
+//
+//	func TestOpenAccount(t *testing.T) {
+//		client := form3test.NewClient(t)
+//		account, _, err := client.Accounts().Create(ctx, &form3.Account{...})
+//		...
+//	}
+//
+// Use NewFake and NewClientWithFake to seed accounts before the test, or inspect them after.
+// The fake also serves the other resources of the API, e.g. subscriptions and organisations; see Fake.SeedResources.
+package form3test
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+)
+
+// NewClient starts an empty fake Form3 API and returns a client wired up to it, with logging disabled.
+// The fake is shut down when the test finishes.
+func NewClient(t testing.TB, options ...form3.ClientOptionFunc) *form3.Client {
+	return NewClientWithFake(t, NewFake(), options...)
+}
+
+// NewClientWithFake serves fake for the duration of the test and returns a client wired up to it, with logging disabled.
+// Options are applied after the defaults, so they can e.g. turn logging back on.
+func NewClientWithFake(t testing.TB, fake *Fake, options ...form3.ClientOptionFunc) *form3.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defaults := []form3.ClientOptionFunc{
+		form3.SetScheme(u.Scheme),
+		form3.SetHost(u.Host),
+		form3.SetInfoLog(nil),
+		form3.SetErrorLog(nil),
+	}
+
+	client, err := form3.NewClient(append(defaults, options...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
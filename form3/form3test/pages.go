@@ -0,0 +1,111 @@
+package form3test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+)
+
+// writePage writes the page of matched resources requested by the page[number] and page[size] params,
+// with links to the other pages of the list at path.
+func writePage[T any](w http.ResponseWriter, params url.Values, path string, matched []T) {
+	size := defaultPageSize
+	if s := params.Get("page[size]"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, "invalid page size")
+			return
+		}
+		if n < maxPageSize {
+			size = n
+		} else {
+			size = maxPageSize
+		}
+	}
+
+	last := 0
+	if len(matched) > 0 {
+		last = (len(matched) - 1) / size
+	}
+
+	number := 0
+	switch n := params.Get("page[number]"); n {
+	case "", "first":
+	case "last":
+		number = last
+	default:
+		var err error
+		if number, err = strconv.Atoi(n); err != nil || number < 0 {
+			writeError(w, http.StatusBadRequest, "invalid page number")
+			return
+		}
+	}
+
+	page := []T{}
+	if start := number * size; start < len(matched) {
+		end := start + size
+		if end > len(matched) {
+			end = len(matched)
+		}
+		page = matched[start:end]
+	}
+
+	links := form3.Links{
+		First: pageLink(path, params, 0, size),
+		Last:  pageLink(path, params, last, size),
+		Self:  pageLink(path, params, number, size),
+	}
+	if number < last {
+		links.Next = pageLink(path, params, number+1, size)
+	}
+	if number > 0 {
+		links.Prev = pageLink(path, params, number-1, size)
+	}
+
+	writeJSON(w, http.StatusOK, form3.ListEnvelope[T]{Data: page, Links: links})
+}
+
+// matchesFilters reports whether a resource matches every filter[{attribute}] param, value returning its attributes.
+// A filter matches if the attribute equals any of its comma separated values.
+func matchesFilters(params url.Values, value func(name string) string) bool {
+	for _, key := range sortedKeys(params) {
+		if !strings.HasPrefix(key, "filter[") || !strings.HasSuffix(key, "]") {
+			continue
+		}
+		got := value(key[len("filter[") : len(key)-1])
+
+		matched := false
+		for _, want := range strings.Split(params.Get(key), ",") {
+			if got == want {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func selfLink(path, id string) *form3.Links {
+	self := fmt.Sprintf("%s/%s", path, id)
+	return &form3.Links{Self: &self}
+}
+
+// pageLink returns the link to page number of the list at path, keeping its filters.
+func pageLink(path string, params url.Values, number, size int) *string {
+	query := url.Values{}
+	for key, values := range params {
+		query[key] = values
+	}
+	query.Set("page[number]", strconv.Itoa(number))
+	query.Set("page[size]", strconv.Itoa(size))
+
+	link := fmt.Sprintf("%s?%s", path, query.Encode())
+	return &link
+}
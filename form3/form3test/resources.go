@@ -0,0 +1,280 @@
+package form3test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+)
+
+// resourcePaths maps the resource types the fake serves besides accounts to their endpoints.
+var resourcePaths = map[string]string{
+	"bankids":       "/v1/organisation/bankids",
+	"bics":          "/v1/organisation/bics",
+	"claims":        "/v1/transaction/claims",
+	"limits":        "/v1/limits",
+	"organisations": "/v1/organisation/units",
+	"roles":         "/v1/security/roles",
+	"subscriptions": "/v1/notification/subscriptions",
+	"users":         "/v1/security/users",
+}
+
+// Resource is a stored resource other than an account, as the JSON object it was created with.
+type Resource map[string]interface{}
+
+// NewResource converts v, e.g. a form3.Subscription, into a Resource.
+func NewResource(v interface{}) (Resource, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return decodeResource(b)
+}
+
+func decodeResource(b []byte) (Resource, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var resource Resource
+	if err := dec.Decode(&resource); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+func (r Resource) clone() Resource {
+	b, _ := json.Marshal(r)
+	resource, _ := decodeResource(b)
+	return resource
+}
+
+func (r Resource) id() string {
+	id, _ := r["id"].(string)
+	return id
+}
+
+func (r Resource) version() int {
+	version, _ := strconv.Atoi(fmt.Sprint(r["version"]))
+	return version
+}
+
+// value returns the named top level member or attribute of the resource, formatted as in a filter.
+func (r Resource) value(name string) string {
+	value, ok := r[name]
+	if !ok {
+		attributes, _ := r["attributes"].(map[string]interface{})
+		if value, ok = attributes[name]; !ok {
+			return ""
+		}
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
+
+// resources holds the stored resources of one type. Unlike accounts, their attributes are not validated.
+type resources struct {
+	resourceType string
+	path         string
+	byID         map[string]Resource
+	order        []string // resource IDs in creation order, the order they are listed in
+}
+
+func newResources(resourceType, path string) *resources {
+	return &resources{
+		resourceType: resourceType,
+		path:         path,
+		byID:         map[string]Resource{},
+	}
+}
+
+func (rs *resources) seed(resource Resource) {
+	id := resource.id()
+	if _, ok := rs.byID[id]; !ok {
+		rs.order = append(rs.order, id)
+	}
+	rs.byID[id] = resource
+}
+
+func (rs *resources) all() []Resource {
+	all := make([]Resource, 0, len(rs.order))
+	for _, id := range rs.order {
+		all = append(all, rs.byID[id].clone())
+	}
+	return all
+}
+
+// serve implements the endpoints of the resource type, as for accounts:
+//
+//	GET    {path}?page[number]=&page[size]=&filter[{attribute}]=
+//	POST   {path}
+//	GET    {path}/{id}
+//	PATCH  {path}/{id}
+//	DELETE {path}/{id}?version=
+func (rs *resources) serve(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, rs.path)
+	switch {
+	case id == "" || id == "/":
+		switch r.Method {
+		case http.MethodGet:
+			rs.list(w, r)
+		case http.MethodPost:
+			rs.create(w, r)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+
+	case !strings.Contains(id[1:], "/"):
+		id = id[1:]
+		if !isUUID(id) {
+			writeError(w, http.StatusBadRequest, "id is not a valid uuid")
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			rs.fetch(w, id)
+		case http.MethodPatch:
+			rs.update(w, r, id)
+		case http.MethodDelete:
+			rs.delete(w, r, id)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no route for %s", r.URL.Path))
+	}
+}
+
+func (rs *resources) list(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	var matched []Resource
+	for _, id := range rs.order {
+		if resource := rs.byID[id]; matchesFilters(params, resource.value) {
+			matched = append(matched, resource)
+		}
+	}
+
+	writePage(w, params, rs.path, matched)
+}
+
+// decode reads the resource in the request body, checking the members every resource has.
+func (rs *resources) decode(w http.ResponseWriter, r *http.Request) (Resource, bool) {
+	var payload struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return nil, false
+	}
+	resource, err := decodeResource(payload.Data)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+		return nil, false
+	}
+
+	var failures []string
+	if id := resource.id(); !isUUID(id) {
+		failures = append(failures, fmt.Sprintf("id in body must be of type uuid: %q", id))
+	}
+	switch resourceType := resource["type"]; {
+	case resourceType == nil || resourceType == "":
+		resource["type"] = rs.resourceType
+	case resourceType != rs.resourceType:
+		failures = append(failures, fmt.Sprintf("type in body should be one of [%s]", rs.resourceType))
+	}
+	if len(failures) > 0 {
+		writeValidationError(w, failures)
+		return nil, false
+	}
+	return resource, true
+}
+
+func (rs *resources) create(w http.ResponseWriter, r *http.Request) {
+	resource, ok := rs.decode(w, r)
+	if !ok {
+		return
+	}
+
+	id := resource.id()
+	if _, ok := rs.byID[id]; ok {
+		writeError(w, http.StatusConflict, "Resource cannot be created as it violates a duplicate constraint")
+		return
+	}
+
+	resource["version"] = 0
+	rs.seed(resource)
+
+	writeJSON(w, http.StatusCreated, form3.DataEnvelope[Resource]{Data: resource, Links: selfLink(rs.path, id)})
+}
+
+func (rs *resources) fetch(w http.ResponseWriter, id string) {
+	resource, ok := rs.byID[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("record %s does not exist", id))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, form3.DataEnvelope[Resource]{Data: resource, Links: selfLink(rs.path, id)})
+}
+
+func (rs *resources) update(w http.ResponseWriter, r *http.Request, id string) {
+	resource, ok := rs.decode(w, r)
+	if !ok {
+		return
+	}
+	if resource.id() != id {
+		writeError(w, http.StatusBadRequest, "id in body does not match id in path")
+		return
+	}
+
+	stored, ok := rs.byID[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("record %s does not exist", id))
+		return
+	}
+	if resource.version() != stored.version() {
+		writeError(w, http.StatusConflict, "invalid version")
+		return
+	}
+
+	resource["version"] = stored.version() + 1
+	rs.byID[id] = resource
+
+	writeJSON(w, http.StatusOK, form3.DataEnvelope[Resource]{Data: resource, Links: selfLink(rs.path, id)})
+}
+
+func (rs *resources) delete(w http.ResponseWriter, r *http.Request, id string) {
+	version, err := strconv.Atoi(r.URL.Query().Get("version"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid version number")
+		return
+	}
+
+	stored, ok := rs.byID[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("record %s does not exist", id))
+		return
+	}
+	if version != stored.version() {
+		writeError(w, http.StatusConflict, "invalid version")
+		return
+	}
+
+	delete(rs.byID, id)
+	for i, ordered := range rs.order {
+		if ordered == id {
+			rs.order = append(rs.order[:i], rs.order[i+1:]...)
+			break
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,67 @@
+package form3test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+)
+
+// Snapshot is the state of a Fake, as saved to and loaded from JSON files.
+type Snapshot struct {
+	Accounts  []form3.Account       `json:"accounts"`
+	Resources map[string][]Resource `json:"resources,omitempty"` // by resource type, e.g. "subscriptions"
+}
+
+// Snapshot returns the current state of the fake.
+func (f *Fake) Snapshot() Snapshot {
+	snapshot := Snapshot{Accounts: f.Accounts()}
+	for resourceType := range resourcePaths {
+		if resources := f.Resources(resourceType); len(resources) > 0 {
+			if snapshot.Resources == nil {
+				snapshot.Resources = map[string][]Resource{}
+			}
+			snapshot.Resources[resourceType] = resources
+		}
+	}
+	return snapshot
+}
+
+// Restore replaces the state of the fake with snapshot. Resources of types the fake does not serve are ignored.
+func (f *Fake) Restore(snapshot Snapshot) {
+	f.Reset()
+	f.Seed(snapshot.Accounts...)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for resourceType, resources := range snapshot.Resources {
+		if store, ok := f.resources[resourceType]; ok {
+			for _, resource := range resources {
+				store.seed(resource.clone())
+			}
+		}
+	}
+}
+
+// ReadSnapshot decodes a snapshot written by WriteSnapshot.
+func ReadSnapshot(r io.Reader) (Snapshot, error) {
+	var snapshot Snapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return snapshot, err
+	}
+
+	for resourceType := range snapshot.Resources {
+		if _, ok := resourcePaths[resourceType]; !ok {
+			return snapshot, fmt.Errorf("snapshot has %q resources, which the fake does not serve", resourceType)
+		}
+	}
+	return snapshot, nil
+}
+
+// WriteSnapshot encodes snapshot as indented JSON.
+func WriteSnapshot(w io.Writer, snapshot Snapshot) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(snapshot)
+}
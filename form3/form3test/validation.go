@@ -0,0 +1,84 @@
+package form3test
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+)
+
+var (
+	uuidPattern     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	countryPattern  = regexp.MustCompile(`^[A-Z]{2}$`)
+	currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+	bicPattern      = regexp.MustCompile(`^([A-Z]{6}[A-Z0-9]{2}|[A-Z]{6}[A-Z0-9]{5})$`)
+	ibanPattern     = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z0-9]{0,64}$`)
+)
+
+func isUUID(s string) bool {
+	return uuidPattern.MatchString(s)
+}
+
+// validateAccount checks account against the rules of the accounts API,
+// returning a failure in the API's wording for each rule it breaks.
+func validateAccount(account *form3.Account) []string {
+	var failures []string
+	fail := func(format string, args ...interface{}) {
+		failures = append(failures, fmt.Sprintf(format, args...))
+	}
+
+	switch {
+	case account.ID == "":
+		fail("id in body is required")
+	case !isUUID(account.ID):
+		fail("id in body must be of type uuid: %q", account.ID)
+	}
+
+	switch {
+	case account.OrganisationID == "":
+		fail("organisation_id in body is required")
+	case !isUUID(account.OrganisationID):
+		fail("organisation_id in body must be of type uuid: %q", account.OrganisationID)
+	}
+
+	if account.Type != "accounts" {
+		fail("type in body should be one of [accounts]")
+	}
+
+	attributes := account.Attributes
+	switch {
+	case attributes.Country == "":
+		fail("country in body is required")
+	case !countryPattern.MatchString(attributes.Country):
+		fail("country in body should match '%s'", countryPattern)
+	}
+
+	if attributes.BaseCurrency != "" && !currencyPattern.MatchString(attributes.BaseCurrency) {
+		fail("base_currency in body should match '%s'", currencyPattern)
+	}
+	if len(attributes.BankID) > 11 {
+		fail("bank_id in body should be at most 11 chars long")
+	}
+	if len(attributes.BankIDCode) > 16 {
+		fail("bank_id_code in body should be at most 16 chars long")
+	}
+	if attributes.Bic != "" && !bicPattern.MatchString(attributes.Bic) {
+		fail("bic in body should match '%s'", bicPattern)
+	}
+	if attributes.Iban != "" && !ibanPattern.MatchString(attributes.Iban) {
+		fail("iban in body should match '%s'", ibanPattern)
+	}
+	switch attributes.AccountClassification {
+	case "", "Personal", "Business":
+	default:
+		fail("account_classification in body should be one of [Personal Business]")
+	}
+
+	return failures
+}
+
+func writeValidationError(w http.ResponseWriter, failures []string) {
+	writeError(w, http.StatusBadRequest, "validation failure list:\n"+strings.Join(failures, "\n"))
+}
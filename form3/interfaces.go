@@ -0,0 +1,161 @@
+package form3
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// The interfaces below are implemented by the services of this package and returned by the Client,
+// so code using the client can depend on them and be tested with the mocks in form3mock.
+//
+// Mocks are generated from this file: run go generate ./form3/form3mock after changing it.
+
+// AccountsAPI is the interface of AccountsService.
+type AccountsAPI interface {
+	Fetch(ctx context.Context, id string, options ...RequestOption) (*Account, *http.Response, error)
+	List(ctx context.Context, options ...RequestOption) ([]Account, *http.Response, error)
+	ListAll(ctx context.Context, options ...RequestOption) ([]Account, *http.Response, error)
+	Create(ctx context.Context, account *Account) (*Account, *http.Response, error)
+	Update(ctx context.Context, account *Account) (*Account, *http.Response, error)
+	Delete(ctx context.Context, id string, version int) (bool, *http.Response, error)
+	CheckRegistration(ctx context.Context, account *Account) error
+	History(ctx context.Context, id string) ([]AccountChange, *http.Response, error)
+	Number(number int) AccountsAPI
+	Size(size int) AccountsAPI
+	Filter(attribute string, values ...string) AccountsAPI
+}
+
+// SubscriptionsAPI is the interface of SubscriptionsService.
+type SubscriptionsAPI interface {
+	Fetch(ctx context.Context, id string) (*Subscription, *http.Response, error)
+	List(ctx context.Context) ([]Subscription, *http.Response, error)
+	Create(ctx context.Context, subscription *Subscription) (*Subscription, *http.Response, error)
+	Update(ctx context.Context, subscription *Subscription) (*Subscription, *http.Response, error)
+	Deactivate(ctx context.Context, subscription *Subscription) (*Subscription, *http.Response, error)
+	Delete(ctx context.Context, id string, version int) (bool, *http.Response, error)
+	Number(number int) SubscriptionsAPI
+	Size(size int) SubscriptionsAPI
+}
+
+// OrganisationsAPI is the interface of OrganisationsService.
+type OrganisationsAPI interface {
+	Fetch(ctx context.Context, id string) (*Organisation, *http.Response, error)
+	List(ctx context.Context) ([]Organisation, *http.Response, error)
+	Children(ctx context.Context, parentID string) ([]Organisation, *http.Response, error)
+	Create(ctx context.Context, organisation *Organisation) (*Organisation, *http.Response, error)
+	Update(ctx context.Context, organisation *Organisation) (*Organisation, *http.Response, error)
+	Walk(ctx context.Context, rootID string, fn WalkFunc) error
+	Number(number int) OrganisationsAPI
+	Size(size int) OrganisationsAPI
+}
+
+// BankIDsAPI is the interface of BankIDsService.
+type BankIDsAPI interface {
+	Fetch(ctx context.Context, id string) (*BankID, *http.Response, error)
+	List(ctx context.Context) ([]BankID, *http.Response, error)
+	Create(ctx context.Context, bankID *BankID) (*BankID, *http.Response, error)
+	Delete(ctx context.Context, id string, version int) (bool, *http.Response, error)
+	IsRegistered(ctx context.Context, organisationID, bankID, bankIDCode string) (bool, error)
+	Number(number int) BankIDsAPI
+	Size(size int) BankIDsAPI
+}
+
+// BicsAPI is the interface of BicsService.
+type BicsAPI interface {
+	Fetch(ctx context.Context, id string) (*Bic, *http.Response, error)
+	List(ctx context.Context) ([]Bic, *http.Response, error)
+	Create(ctx context.Context, bic *Bic) (*Bic, *http.Response, error)
+	Delete(ctx context.Context, id string, version int) (bool, *http.Response, error)
+	IsRegistered(ctx context.Context, organisationID, bic string) (bool, error)
+	Number(number int) BicsAPI
+	Size(size int) BicsAPI
+}
+
+// SecurityAPI is the interface of SecurityService.
+type SecurityAPI interface {
+	FetchUser(ctx context.Context, id string) (*User, *http.Response, error)
+	ListUsers(ctx context.Context) ([]User, *http.Response, error)
+	CreateUser(ctx context.Context, user *User) (*User, *http.Response, error)
+	DeleteUser(ctx context.Context, id string, version int) (bool, *http.Response, error)
+	FetchRole(ctx context.Context, id string) (*Role, *http.Response, error)
+	ListRoles(ctx context.Context) ([]Role, *http.Response, error)
+	CreateRole(ctx context.Context, role *Role) (*Role, *http.Response, error)
+	DeleteRole(ctx context.Context, id string, version int) (bool, *http.Response, error)
+	ListACEs(ctx context.Context, roleID string) ([]ACE, *http.Response, error)
+	CreateACE(ctx context.Context, roleID string, ace *ACE) (*ACE, *http.Response, error)
+	DeleteACE(ctx context.Context, roleID, id string, version int) (bool, *http.Response, error)
+	ListCredentials(ctx context.Context, userID string) ([]Credential, *http.Response, error)
+	UploadPublicKey(ctx context.Context, userID, publicKeyPEM string) (*Credential, *http.Response, error)
+	DeleteCredential(ctx context.Context, userID, id string) (bool, *http.Response, error)
+	Diff(ctx context.Context, organisationID string, desired []RoleSpec, prune bool) (*SecurityPlan, error)
+	Apply(ctx context.Context, plan *SecurityPlan) error
+	Converge(ctx context.Context, organisationID string, desired []RoleSpec, prune bool) (*SecurityPlan, error)
+	Number(number int) SecurityAPI
+	Size(size int) SecurityAPI
+}
+
+// ReportsAPI is the interface of ReportsService.
+type ReportsAPI interface {
+	Request(ctx context.Context, report *Report) (*Report, *http.Response, error)
+	Fetch(ctx context.Context, id string) (*Report, *http.Response, error)
+	Wait(ctx context.Context, id string) (*Report, error)
+	Download(ctx context.Context, report *Report, w io.Writer) (int64, *http.Response, error)
+	PollInterval(interval time.Duration) ReportsAPI
+	Attempts(attempts int) ReportsAPI
+}
+
+// AuditAPI is the interface of AuditService.
+type AuditAPI interface {
+	List(ctx context.Context) ([]AuditEntry, *http.Response, error)
+	ListAll(ctx context.Context) ([]AuditEntry, *http.Response, error)
+	RecordType(recordType string) AuditAPI
+	RecordID(recordID string) AuditAPI
+	Actor(userID string) AuditAPI
+	Between(from, to time.Time) AuditAPI
+	Number(number int) AuditAPI
+	Size(size int) AuditAPI
+}
+
+// LimitsAPI is the interface of LimitsService.
+type LimitsAPI interface {
+	Fetch(ctx context.Context, id string) (*Limit, *http.Response, error)
+	List(ctx context.Context) ([]Limit, *http.Response, error)
+	ListForScheme(ctx context.Context, scheme string) ([]Limit, *http.Response, error)
+	CanSend(ctx context.Context, scheme string, amount Amount, currency Currency) (bool, error)
+	Number(number int) LimitsAPI
+	Size(size int) LimitsAPI
+}
+
+// ClaimsAPI is the interface of ClaimsService.
+type ClaimsAPI interface {
+	Fetch(ctx context.Context, id string, options ...RequestOption) (*Claim, *http.Response, error)
+	List(ctx context.Context) ([]Claim, *http.Response, error)
+	Create(ctx context.Context, claim *Claim) (*Claim, *http.Response, error)
+	Submit(ctx context.Context, claim *Claim) (*ClaimSubmission, *http.Response, error)
+	FetchSubmission(ctx context.Context, claimID, id string) (*ClaimSubmission, *http.Response, error)
+	ListSubmissions(ctx context.Context, claimID string) ([]ClaimSubmission, *http.Response, error)
+	Number(number int) ClaimsAPI
+	Size(size int) ClaimsAPI
+}
+
+// RoutingAPI is the interface of RoutingService.
+type RoutingAPI interface {
+	Lookup(ctx context.Context, attributes AccountAttributes) (*Route, *http.Response, error)
+	Reachable(ctx context.Context, attributes AccountAttributes, scheme string) (bool, error)
+}
+
+var (
+	_ AccountsAPI      = (*AccountsService)(nil)
+	_ SubscriptionsAPI = (*SubscriptionsService)(nil)
+	_ OrganisationsAPI = (*OrganisationsService)(nil)
+	_ BankIDsAPI       = (*BankIDsService)(nil)
+	_ BicsAPI          = (*BicsService)(nil)
+	_ SecurityAPI      = (*SecurityService)(nil)
+	_ ReportsAPI       = (*ReportsService)(nil)
+	_ AuditAPI         = (*AuditService)(nil)
+	_ LimitsAPI        = (*LimitsService)(nil)
+	_ ClaimsAPI        = (*ClaimsService)(nil)
+	_ RoutingAPI       = (*RoutingService)(nil)
+)
@@ -0,0 +1,188 @@
+package form3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	limitsPath string = "/limits"
+)
+
+// ErrNoLimit is returned by CanSend when no limit applies to the scheme and currency.
+var ErrNoLimit = errors.New("no limit found")
+
+// Limit types.
+const (
+	// LimitTypeNetSenderCap is the scheme-imposed cap on net outbound value within a settlement cycle (e.g. the FPS NSC).
+	LimitTypeNetSenderCap string = "net_sender_cap"
+	// LimitTypeGateway is a limit applied by a specific Form3 gateway.
+	LimitTypeGateway string = "gateway"
+)
+
+// Limit represents a cap on the value that can be sent through a payment scheme, and how much of it has been used.
+type Limit struct {
+	Attributes     LimitAttributes `json:"attributes"`
+	ID             string          `json:"id"`
+	OrganisationID string          `json:"organisation_id"`
+	Type           string          `json:"type"`
+	Version        int             `json:"version"`
+}
+
+// LimitAttributes represents attributes of a Limit
+type LimitAttributes struct {
+	Scheme     string   `json:"scheme"`
+	Gateway    string   `json:"gateway,omitempty"`
+	LimitType  string   `json:"limit_type"`
+	Currency   Currency `json:"currency"`
+	Amount     Amount   `json:"amount"`
+	UsedAmount Amount   `json:"used_amount"`
+}
+
+// Remaining returns how much more can be sent before the limit is reached.
+func (l *Limit) Remaining() Amount {
+	return l.Attributes.Amount.Sub(l.Attributes.UsedAmount)
+}
+
+// LimitsService implements a service to query scheme limits and their utilisation
+type LimitsService struct {
+	client     *Client
+	pagination Pagination
+}
+
+// NewLimitsService creates a new LimitsService.
+func NewLimitsService(client *Client) *LimitsService {
+	return &LimitsService{
+		client:     client,
+		pagination: NewPagination(),
+	}
+}
+
+type fetchLimitAPIResponse struct {
+	Data  Limit `json:"data"`
+	Links Links `json:"links"`
+}
+
+type listLimitsAPIResponse struct {
+	Data  []Limit `json:"data"`
+	Links Links   `json:"links"`
+}
+
+// Fetch -> Get a single limit, including its current utilisation, using the limit ID.
+//
+// GET /v1/limits/{limit_id}
+func (s *LimitsService) Fetch(ctx context.Context, id string) (*Limit, *http.Response, error) {
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%s", limitsPath, id),
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret fetchLimitAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	return &ret.Data, res, nil
+}
+
+// List -> List limits, paged using Number and Size.
+//
+// GET /v1/limits?page[number]={page_number}&page[size]={page_size}
+func (s *LimitsService) List(ctx context.Context) ([]Limit, *http.Response, error) {
+	ret, res, err := s.list(ctx, s.pagination.Params())
+	if err != nil {
+		return nil, res, err
+	}
+	return ret.Data, res, nil
+}
+
+// ListForScheme -> List every limit that applies to a scheme (e.g. "FPS"), following all pages.
+//
+// GET /v1/limits?filter[scheme]={scheme}
+func (s *LimitsService) ListForScheme(ctx context.Context, scheme string) ([]Limit, *http.Response, error) {
+	var limits []Limit
+
+	page := Pagination{Number: 0, Size: s.pagination.Size}
+	for {
+		params := page.Params()
+		params.Add("filter[scheme]", scheme)
+
+		ret, res, err := s.list(ctx, params)
+		if err != nil {
+			return nil, res, err
+		}
+
+		for _, limit := range ret.Data {
+			if limit.Attributes.Scheme == scheme {
+				limits = append(limits, limit)
+			}
+		}
+
+		if lastPage(len(ret.Data), ret.Links) {
+			return limits, res, nil
+		}
+		page.Number++
+	}
+}
+
+// CanSend -> Report whether amount, in currency, can be sent through scheme without exceeding any of its limits.
+// Limits in other currencies do not apply. It returns ErrNoLimit when scheme has no limit in currency, e.g. because
+// the scheme is misspelled, rather than assuming the amount can be sent.
+// Use it as a pre-flight check before releasing a batch; limits can still be consumed by other payments in the meantime.
+func (s *LimitsService) CanSend(ctx context.Context, scheme string, amount Amount, currency Currency) (bool, error) {
+	limits, _, err := s.ListForScheme(ctx, scheme)
+	if err != nil {
+		return false, err
+	}
+
+	matched := 0
+	for i := range limits {
+		if limits[i].Attributes.Currency != currency {
+			continue
+		}
+		matched++
+		if limits[i].Remaining().Cmp(amount) < 0 {
+			return false, nil
+		}
+	}
+
+	if matched == 0 {
+		return false, fmt.Errorf("%w for scheme %s in %s", ErrNoLimit, scheme, currency)
+	}
+	return true, nil
+}
+
+// Number -> page number requested. Defaults to 0.
+func (s *LimitsService) Number(number int) LimitsAPI {
+	s.pagination.Number = number
+	return s
+}
+
+// Size -> size is the max number of resources to return. Defaults to 10.
+func (s *LimitsService) Size(size int) LimitsAPI {
+	s.pagination.Size = size
+	return s
+}
+
+func (s *LimitsService) list(ctx context.Context, params url.Values) (*listLimitsAPIResponse, *http.Response, error) {
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "GET",
+		Path:   limitsPath,
+		Params: params,
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret listLimitsAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+	return &ret, res, nil
+}
@@ -0,0 +1,130 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func Test_FetchLimit_Success(t *testing.T) {
+	client, srv := testClient("/v1/limits/9b6d2f4e-1a3c-4e5f-8a7b-6c5d4e3f2a10", http.StatusOK, limitJSON)
+	defer srv.Close()
+
+	limit, res, err := client.Limits().Fetch(context.Background(), "9b6d2f4e-1a3c-4e5f-8a7b-6c5d4e3f2a10")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if http.StatusOK != res.StatusCode {
+		t.Error("Expected:", http.StatusOK, "Got:", res.StatusCode)
+	}
+
+	if limit.Remaining().String() != "249999.79" {
+		t.Error("Expected: 249999.79", "Got:", limit.Remaining())
+	}
+}
+
+func Test_CanSend(t *testing.T) {
+	client, srv := testClient("/v1/limits", http.StatusOK, limitsJSON)
+	defer srv.Close()
+
+	tests := []struct {
+		amount   string
+		expected bool
+	}{
+		{"1000.00", true},
+		{"5000.00", true},
+		{"5000.01", false},
+	}
+
+	for _, tt := range tests {
+		ok, err := client.Limits().CanSend(context.Background(), "FPS", MustParseAmount(tt.amount), GBP)
+		if err != nil {
+			t.Error(err)
+		}
+		if ok != tt.expected {
+			t.Error("CanSend", tt.amount, "Expected:", tt.expected, "Got:", ok)
+		}
+	}
+}
+
+func Test_CanSend_NoLimit_Failure(t *testing.T) {
+	client, srv := testClient("/v1/limits", http.StatusOK, limitsJSON)
+	defer srv.Close()
+
+	// A misspelled scheme, and a currency the scheme has no limit in, are not allowances
+	for _, tt := range []struct {
+		scheme   string
+		currency Currency
+	}{
+		{"FSP", GBP},
+		{"FPS", EUR},
+	} {
+		ok, err := client.Limits().CanSend(context.Background(), tt.scheme, MustParseAmount("1.00"), tt.currency)
+		if ok || !errors.Is(err, ErrNoLimit) {
+			t.Error("CanSend", tt.scheme, tt.currency, "Expected:", ErrNoLimit, "Got:", ok, err)
+		}
+	}
+}
+
+func Test_CanSend_SizeAboveCap_Success(t *testing.T) {
+	srv := serverMock("/v1/limits", func(w http.ResponseWriter, r *http.Request) {
+		start, end, links := cappedPage(r, 5, 2)
+
+		// Only the last limit is in GBP
+		var data []Limit
+		for i := start; i < end; i++ {
+			limit := Limit{ID: fmt.Sprint(i), Attributes: LimitAttributes{Scheme: "FPS", Currency: EUR, Amount: MustParseAmount("100.00")}}
+			if i == 4 {
+				limit.Attributes.Currency = GBP
+			}
+			data = append(data, limit)
+		}
+		json.NewEncoder(w).Encode(listLimitsAPIResponse{Data: data, Links: links})
+	})
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	ok, err := client.Limits().Size(3).CanSend(context.Background(), "FPS", MustParseAmount("1.00"), GBP)
+	if !ok || err != nil {
+		t.Error("Expected:", true, "Got:", ok, err)
+	}
+}
+
+var limitJSON = `{
+	"data": {
+		"type": "limits",
+		"id": "9b6d2f4e-1a3c-4e5f-8a7b-6c5d4e3f2a10",
+		"version": 0,
+		"organisation_id": "158f775d-4ecd-4861-b33d-30df9a29de78",
+		"attributes": {
+			"scheme": "FPS",
+			"limit_type": "net_sender_cap",
+			"currency": "GBP",
+			"amount": "250000.00",
+			"used_amount": "0.21"
+		}
+	}
+}`
+
+var limitsJSON = `{
+	"data": [{
+		"type": "limits",
+		"id": "9b6d2f4e-1a3c-4e5f-8a7b-6c5d4e3f2a10",
+		"attributes": {"scheme": "FPS", "limit_type": "net_sender_cap", "currency": "GBP", "amount": "250000.00", "used_amount": "240000.00"}
+	}, {
+		"type": "limits",
+		"id": "9b6d2f4e-1a3c-4e5f-8a7b-6c5d4e3f2a11",
+		"attributes": {"scheme": "FPS", "gateway": "fps-gw-1", "limit_type": "gateway", "currency": "GBP", "amount": "20000.00", "used_amount": "15000.00"}
+	}, {
+		"type": "limits",
+		"id": "9b6d2f4e-1a3c-4e5f-8a7b-6c5d4e3f2a12",
+		"attributes": {"scheme": "SEPAINSTANT", "limit_type": "gateway", "currency": "EUR", "amount": "100.00", "used_amount": "100.00"}
+	}],
+	"links": {
+		"self": "/v1/limits?page[number]=0&page[size]=10"
+	}
+}`
@@ -0,0 +1,273 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	organisationsPath string = "/organisation/units"
+)
+
+// Organisation represents an organisation (or organisation unit) registered with Form3.
+// Organisations form a hierarchy: OrganisationID is the ID of the parent organisation.
+type Organisation struct {
+	Attributes     OrganisationAttributes     `json:"attributes"`
+	ID             string                     `json:"id"`
+	OrganisationID string                     `json:"organisation_id,omitempty"`
+	Type           string                     `json:"type"`
+	Version        int                        `json:"version"`
+	Extra          map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the Organisation, keeping members not known to this client in Extra.
+func (o *Organisation) UnmarshalJSON(b []byte) error {
+	type organisation Organisation
+	extra, err := unmarshalExtra(b, (*organisation)(o))
+	if err != nil {
+		return err
+	}
+	o.Extra = extra
+	return nil
+}
+
+// MarshalJSON encodes the Organisation, including the members in Extra.
+func (o Organisation) MarshalJSON() ([]byte, error) {
+	type organisation Organisation
+	return marshalExtra(organisation(o), o.Extra)
+}
+
+// OrganisationAttributes represents attributes of an Organisation
+type OrganisationAttributes struct {
+	Name  string                     `json:"name"`
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the OrganisationAttributes, keeping members not known to this client in Extra.
+func (o *OrganisationAttributes) UnmarshalJSON(b []byte) error {
+	type organisationAttributes OrganisationAttributes
+	extra, err := unmarshalExtra(b, (*organisationAttributes)(o))
+	if err != nil {
+		return err
+	}
+	o.Extra = extra
+	return nil
+}
+
+// MarshalJSON encodes the OrganisationAttributes, including the members in Extra.
+func (o OrganisationAttributes) MarshalJSON() ([]byte, error) {
+	type organisationAttributes OrganisationAttributes
+	return marshalExtra(organisationAttributes(o), o.Extra)
+}
+
+// OrganisationsService implements a service to manage organisations and their units
+// See https://api-docs.form3.tech/api.html#organisation-units
+type OrganisationsService struct {
+	client     *Client
+	pagination Pagination
+}
+
+// NewOrganisationsService creates a new OrganisationsService.
+func NewOrganisationsService(client *Client) *OrganisationsService {
+	return &OrganisationsService{
+		client:     client,
+		pagination: NewPagination(),
+	}
+}
+
+type fetchOrganisationAPIResponse struct {
+	Data  Organisation `json:"data"`
+	Links Links        `json:"links"`
+}
+
+type listOrganisationsAPIResponse struct {
+	Data  []Organisation `json:"data"`
+	Links Links          `json:"links"`
+}
+
+type organisationAPIPayload struct {
+	Data Organisation `json:"data"`
+}
+
+// Fetch -> Get a single organisation using the organisation ID.
+//
+// GET /v1/organisation/units/{organisation_id}
+func (s *OrganisationsService) Fetch(ctx context.Context, id string) (*Organisation, *http.Response, error) {
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%s", organisationsPath, id),
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret fetchOrganisationAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	return &ret.Data, res, nil
+}
+
+// List -> List organisations visible to the caller, paged using Number and Size.
+//
+// GET /v1/organisation/units?page[number]={page_number}&page[size]={page_size}
+func (s *OrganisationsService) List(ctx context.Context) ([]Organisation, *http.Response, error) {
+	ret, res, err := s.list(ctx, s.pagination.Params())
+	if err != nil {
+		return nil, res, err
+	}
+	return ret.Data, res, nil
+}
+
+// Children -> List every direct child unit of the parent organisation, following all pages.
+//
+// GET /v1/organisation/units?filter[organisation_id]={parent_id}
+func (s *OrganisationsService) Children(ctx context.Context, parentID string) ([]Organisation, *http.Response, error) {
+	var children []Organisation
+
+	page := Pagination{Number: 0, Size: s.pagination.Size}
+	for {
+		params := page.Params()
+		params.Add("filter[organisation_id]", parentID)
+
+		ret, res, err := s.list(ctx, params)
+		if err != nil {
+			return nil, res, err
+		}
+
+		for _, org := range ret.Data {
+			// A root organisation may report itself as its own parent.
+			if org.ID != parentID {
+				children = append(children, org)
+			}
+		}
+
+		if lastPage(len(ret.Data), ret.Links) {
+			return children, res, nil
+		}
+		page.Number++
+	}
+}
+
+// Create -> Create a new organisation unit.
+//
+// POST /v1/organisation/units
+//
+// Set OrganisationID to the parent organisation to create a child unit.
+func (s *OrganisationsService) Create(ctx context.Context, organisation *Organisation) (*Organisation, *http.Response, error) {
+	data := &organisationAPIPayload{Data: *organisation}
+
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "POST",
+		Path:   organisationsPath,
+		Body:   data,
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret fetchOrganisationAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	return &ret.Data, res, nil
+}
+
+// Update -> Update an existing organisation unit.
+//
+// PATCH /v1/organisation/units/{organisation_id}
+//
+// The organisation's ID and current version must be set. A 409 Conflict is returned if the version is out of date.
+func (s *OrganisationsService) Update(ctx context.Context, organisation *Organisation) (*Organisation, *http.Response, error) {
+	data := &organisationAPIPayload{Data: *organisation}
+
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "PATCH",
+		Path:   fmt.Sprintf("%s/%s", organisationsPath, organisation.ID),
+		Body:   data,
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret fetchOrganisationAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	return &ret.Data, res, nil
+}
+
+// WalkFunc is called for each organisation visited by Walk.
+// depth is 0 for the root organisation, 1 for its children and so on.
+type WalkFunc func(organisation *Organisation, depth int) error
+
+// Walk -> Visit the organisation with the given ID and all of its descendants, depth first.
+// Walking stops at the first error returned by fn or by the API.
+func (s *OrganisationsService) Walk(ctx context.Context, rootID string, fn WalkFunc) error {
+	root, _, err := s.Fetch(ctx, rootID)
+	if err != nil {
+		return err
+	}
+
+	visited := map[string]bool{}
+	return s.walk(ctx, root, 0, visited, fn)
+}
+
+func (s *OrganisationsService) walk(ctx context.Context, org *Organisation, depth int, visited map[string]bool, fn WalkFunc) error {
+	// Guard against cycles in a misconfigured hierarchy.
+	if visited[org.ID] {
+		return nil
+	}
+	visited[org.ID] = true
+
+	if err := fn(org, depth); err != nil {
+		return err
+	}
+
+	children, _, err := s.Children(ctx, org.ID)
+	if err != nil {
+		return err
+	}
+
+	for i := range children {
+		if err := s.walk(ctx, &children[i], depth+1, visited, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Number -> page number requested. Defaults to 0.
+func (s *OrganisationsService) Number(number int) OrganisationsAPI {
+	s.pagination.Number = number
+	return s
+}
+
+// Size -> size is the max number of resources to return. Defaults to 10.
+func (s *OrganisationsService) Size(size int) OrganisationsAPI {
+	s.pagination.Size = size
+	return s
+}
+
+func (s *OrganisationsService) list(ctx context.Context, params url.Values) (*listOrganisationsAPIResponse, *http.Response, error) {
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "GET",
+		Path:   organisationsPath,
+		Params: params,
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret listOrganisationsAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+	return &ret, res, nil
+}
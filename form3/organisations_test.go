@@ -0,0 +1,137 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func Test_FetchOrganisation_Success(t *testing.T) {
+	client, srv := testClient("/v1/organisation/units/ee2fb143-6dfe-4787-b183-ca8ddd4164d2", http.StatusOK, organisationJSON)
+	defer srv.Close()
+
+	org, res, err := client.Organisations().Fetch(context.Background(), "ee2fb143-6dfe-4787-b183-ca8ddd4164d2")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if http.StatusOK != res.StatusCode {
+		t.Error("Expected:", http.StatusOK, "Got:", res.StatusCode)
+	}
+
+	if org.Attributes.Name != "Brand A" {
+		t.Error("Expected: Brand A", "Got:", org.Attributes.Name)
+	}
+}
+
+func Test_WalkOrganisations_Success(t *testing.T) {
+	// root -> (a -> (c), b)
+	tree := map[string][]string{
+		"root": {"a", "b"},
+		"a":    {"c"},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/organisation/units/root", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data": {"id": "root", "organisation_id": "root", "type": "organisations"}}`)
+	})
+	mux.HandleFunc("/v1/organisation/units", func(w http.ResponseWriter, r *http.Request) {
+		parent := r.URL.Query().Get("filter[organisation_id]")
+
+		// The root reports itself as its own parent.
+		data := []Organisation{}
+		if r.URL.Query().Get("page[number]") != "0" {
+			json.NewEncoder(w).Encode(listOrganisationsAPIResponse{Data: data})
+			return
+		}
+		if parent == "root" {
+			data = append(data, Organisation{ID: "root", OrganisationID: "root"})
+		}
+		for _, id := range tree[parent] {
+			data = append(data, Organisation{ID: id, OrganisationID: parent})
+		}
+		json.NewEncoder(w).Encode(listOrganisationsAPIResponse{Data: data})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	var visited []string
+	err := client.Organisations().Walk(context.Background(), "root", func(org *Organisation, depth int) error {
+		visited = append(visited, fmt.Sprintf("%s:%d", org.ID, depth))
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := []string{"root:0", "a:1", "c:2", "b:1"}
+	if !reflect.DeepEqual(visited, expected) {
+		t.Error("Expected:", expected, "Got:", visited)
+	}
+}
+
+func Test_Children_SizeAboveCap_Success(t *testing.T) {
+	srv := serverMock("/v1/organisation/units", func(w http.ResponseWriter, r *http.Request) {
+		start, end, links := cappedPage(r, 5, 2)
+
+		var data []Organisation
+		for i := start; i < end; i++ {
+			data = append(data, Organisation{ID: fmt.Sprintf("child-%d", i), OrganisationID: "root"})
+		}
+		json.NewEncoder(w).Encode(listOrganisationsAPIResponse{Data: data, Links: links})
+	})
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	children, _, err := client.Organisations().Size(3).Children(context.Background(), "root")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(children) != 5 {
+		t.Error("Expected:", 5, "Got:", len(children))
+	}
+}
+
+func Test_CreateAccount_DefaultOrganisation_Success(t *testing.T) {
+	var payload DataEnvelope[Account]
+	srv := serverMock("/v1/organisation/accounts", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&payload)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(accountJSON))
+	})
+	defer srv.Close()
+
+	client := testClientFor(srv)
+	SetOrganisationID("ee2fb143-6dfe-4787-b183-ca8ddd4164d2")(client)
+
+	account := &Account{ID: "158f775c-4ecd-4861-b33d-30df9a29de78"}
+	if _, _, err := client.Accounts().Create(context.Background(), account); err != nil {
+		t.Error(err)
+	}
+
+	if payload.Data.OrganisationID != "ee2fb143-6dfe-4787-b183-ca8ddd4164d2" {
+		t.Error("Expected: ee2fb143-6dfe-4787-b183-ca8ddd4164d2", "Got:", payload.Data.OrganisationID)
+	}
+
+	if account.OrganisationID != "" {
+		t.Error("Expected the caller's account to be left unchanged, Got:", account.OrganisationID)
+	}
+}
+
+var organisationJSON = `{
+	"data": {
+		"type": "organisations",
+		"id": "ee2fb143-6dfe-4787-b183-ca8ddd4164d2",
+		"version": 0,
+		"organisation_id": "743d5b63-8e6f-432e-a8fa-c5d8d2ee5fcb",
+		"attributes": {
+			"name": "Brand A"
+		}
+	}
+}`
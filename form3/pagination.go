@@ -40,3 +40,12 @@ func (p *Pagination) Params() url.Values {
 
 	return params
 }
+
+// lastPage reports whether a page of a list is the last one. Servers cap the page size (at 100 for Form3),
+// so a page shorter than the size requested may not be: the next link tells, or an empty page without links.
+func lastPage(found int, links Links) bool {
+	if links.Self != nil {
+		return links.Next == nil || found == 0
+	}
+	return found == 0
+}
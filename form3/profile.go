@@ -0,0 +1,239 @@
+package form3
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"time"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Environment variables read by NewClientFromEnv.
+const (
+	EnvBaseURL        string = "FORM3_BASE_URL"         // e.g. https://api.staging-form3.tech
+	EnvOrganisationID string = "FORM3_ORGANISATION_ID"  // default organisation for created resources
+	EnvToken          string = "FORM3_TOKEN"            // bearer token
+	EnvSigningKeyID   string = "FORM3_SIGNING_KEY_ID"   // public key ID of the signing key
+	EnvSigningKeyPath string = "FORM3_SIGNING_KEY_PATH" // PEM file with the private signing key
+	EnvTimeout        string = "FORM3_TIMEOUT"          // e.g. 30s
+	EnvCABundle       string = "FORM3_CA_BUNDLE"        // PEM file with the CA certificates to trust
+	EnvClientCert     string = "FORM3_CLIENT_CERT"      // PEM file with the client certificate for mutual TLS
+	EnvClientKey      string = "FORM3_CLIENT_KEY"       // PEM file with the key of the client certificate
+)
+
+// Profile holds the settings of a client, e.g. for one environment. Empty settings keep the client's defaults.
+// Load one from the environment with ProfileFromEnv, from config files with the form3config package,
+// or fill one in and apply its Options.
+type Profile struct {
+	BaseURL        string            `yaml:"base_url"` // may have a path prefix, see SetBaseURL
+	OrganisationID string            `yaml:"organisation_id"`
+	Token          string            `yaml:"token"`
+	SigningKeyID   string            `yaml:"signing_key_id"`
+	SigningKeyPath string            `yaml:"signing_key_path"` // relative to the config file, ~ is the home directory
+	Timeout        time.Duration     `yaml:"timeout"`          // until the response headers arrive, see SetTimeout
+	Retry          *RetryPolicy      `yaml:"retry"`
+	APIVersions    map[string]string `yaml:"api_versions"` // by path, see SetAPIVersion
+	CABundle       string            `yaml:"ca_bundle"`    // paths are relative to the config file, like signing_key_path
+	ClientCert     string            `yaml:"client_cert"`
+	ClientKey      string            `yaml:"client_key"`
+	Proxy          string            `yaml:"proxy"` // instead of HTTPS_PROXY and HTTP_PROXY
+	NoProxy        []string          `yaml:"no_proxy"`
+
+	Name string `yaml:"-"` // name in the config file, used in errors
+}
+
+// Options checks the profile's settings and returns the client options applying them.
+// The signing key, CA bundle and client certificate, if any, are read here.
+func (p Profile) Options() ([]ClientOptionFunc, error) {
+	options, err := p.options()
+	if err != nil && p.Name != "" {
+		return nil, fmt.Errorf("profile %q: %w", p.Name, err)
+	}
+	return options, err
+}
+
+func (p Profile) options() ([]ClientOptionFunc, error) {
+	var options []ClientOptionFunc
+
+	if p.BaseURL != "" {
+		u, err := url.Parse(p.BaseURL)
+		if err == nil {
+			err = checkBaseURL(u)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("base_url %q: %w", p.BaseURL, err)
+		}
+		options = append(options, SetBaseURL(u))
+	}
+
+	if p.OrganisationID != "" {
+		if !uuidPattern.MatchString(p.OrganisationID) {
+			return nil, fmt.Errorf("organisation_id %q is not a UUID", p.OrganisationID)
+		}
+		options = append(options, SetOrganisationID(p.OrganisationID))
+	}
+
+	if p.Token != "" {
+		options = append(options, SetAuthToken(p.Token))
+	}
+
+	switch {
+	case p.SigningKeyID != "" && p.SigningKeyPath != "":
+		key, err := LoadSigningKey(p.SigningKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, SetSigningKey(p.SigningKeyID, key))
+	case p.SigningKeyID != "":
+		return nil, errors.New("signing_key_id is set without signing_key_path")
+	case p.SigningKeyPath != "":
+		return nil, errors.New("signing_key_path is set without signing_key_id")
+	}
+
+	if p.Timeout < 0 {
+		return nil, fmt.Errorf("timeout %s is negative", p.Timeout)
+	}
+	if p.Timeout > 0 {
+		options = append(options, SetTimeout(p.Timeout))
+	}
+
+	if p.Retry != nil {
+		if err := p.Retry.validate(); err != nil {
+			return nil, err
+		}
+		options = append(options, SetRetryPolicy(*p.Retry))
+	}
+
+	if p.CABundle != "" {
+		pool, err := loadCABundle(p.CABundle)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, setRootCAs(pool))
+	}
+
+	switch {
+	case p.ClientCert != "" && p.ClientKey != "":
+		certificate, err := tls.LoadX509KeyPair(p.ClientCert, p.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("client certificate: %w", err)
+		}
+		options = append(options, setClientCertificate(certificate))
+	case p.ClientCert != "":
+		return nil, errors.New("client_cert is set without client_key")
+	case p.ClientKey != "":
+		return nil, errors.New("client_key is set without client_cert")
+	}
+
+	if p.Proxy != "" {
+		u, err := url.Parse(p.Proxy)
+		if err == nil {
+			err = checkProxyURL(u)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("proxy %q: %w", p.Proxy, err)
+		}
+		options = append(options, SetProxy(u, p.NoProxy...))
+	}
+
+	for path, version := range p.APIVersions {
+		if err := checkAPIVersion(path, version); err != nil {
+			return nil, fmt.Errorf("api_versions: %w", err)
+		}
+		options = append(options, SetAPIVersion(path, version))
+	}
+
+	return options, nil
+}
+
+// Merge overrides the settings of p with those set in other.
+func (p *Profile) Merge(other Profile) {
+	if other.BaseURL != "" {
+		p.BaseURL = other.BaseURL
+	}
+	if other.OrganisationID != "" {
+		p.OrganisationID = other.OrganisationID
+	}
+	if other.Token != "" {
+		p.Token = other.Token
+	}
+	if other.SigningKeyID != "" {
+		p.SigningKeyID = other.SigningKeyID
+	}
+	if other.SigningKeyPath != "" {
+		p.SigningKeyPath = other.SigningKeyPath
+	}
+	if other.Timeout != 0 {
+		p.Timeout = other.Timeout
+	}
+	if other.Retry != nil {
+		p.Retry = other.Retry
+	}
+	if other.CABundle != "" {
+		p.CABundle = other.CABundle
+	}
+	if other.ClientCert != "" {
+		p.ClientCert = other.ClientCert
+	}
+	if other.ClientKey != "" {
+		p.ClientKey = other.ClientKey
+	}
+	if other.Proxy != "" {
+		p.Proxy = other.Proxy
+		p.NoProxy = other.NoProxy
+	}
+	if len(other.APIVersions) > 0 {
+		versions := make(map[string]string, len(p.APIVersions)+len(other.APIVersions))
+		for path, version := range p.APIVersions {
+			versions[path] = version
+		}
+		for path, version := range other.APIVersions {
+			versions[path] = version
+		}
+		p.APIVersions = versions
+	}
+}
+
+// ProfileFromEnv returns the profile set by the FORM3_BASE_URL, FORM3_ORGANISATION_ID, FORM3_TOKEN,
+// FORM3_SIGNING_KEY_ID, FORM3_SIGNING_KEY_PATH, FORM3_TIMEOUT, FORM3_CA_BUNDLE, FORM3_CLIENT_CERT and FORM3_CLIENT_KEY
+// environment variables, looked up with getenv (e.g. os.Getenv). Unset variables keep the client's defaults.
+func ProfileFromEnv(getenv func(string) string) (Profile, error) {
+	profile := Profile{
+		BaseURL:        getenv(EnvBaseURL),
+		OrganisationID: getenv(EnvOrganisationID),
+		Token:          getenv(EnvToken),
+		SigningKeyID:   getenv(EnvSigningKeyID),
+		SigningKeyPath: getenv(EnvSigningKeyPath),
+		CABundle:       getenv(EnvCABundle),
+		ClientCert:     getenv(EnvClientCert),
+		ClientKey:      getenv(EnvClientKey),
+	}
+	if timeout := getenv(EnvTimeout); timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil {
+			return Profile{}, fmt.Errorf("%s %q is not a duration, e.g. 30s", EnvTimeout, timeout)
+		}
+		profile.Timeout = d
+	}
+	return profile, nil
+}
+
+// NewClientFromEnv creates a new client configured by the environment variables alone, see ProfileFromEnv.
+// Use form3config.NewClientFromEnv to also read config files. options are applied last, so they override the environment.
+// Misconfigurations are reported here rather than on the first request.
+func NewClientFromEnv(options ...ClientOptionFunc) (*Client, error) {
+	profile, err := ProfileFromEnv(os.Getenv)
+	if err != nil {
+		return nil, err
+	}
+
+	profileOptions, err := profile.Options()
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(append(profileOptions, options...)...)
+}
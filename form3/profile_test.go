@@ -0,0 +1,102 @@
+package form3
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Profile_Options_Success(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	profile := Profile{
+		BaseURL:        "https://egress.internal/form3/",
+		SigningKeyID:   "75a8ba12-fff2-4a52-ad8a-e8b34c5ccec8",
+		SigningKeyPath: writeKey(t, key),
+		Timeout:        10 * time.Second,
+	}
+	options, err := profile.Options()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := NewClient(options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.scheme != "https" || client.host != "egress.internal" || client.pathPrefix != "/form3" || client.timeout != 10*time.Second {
+		t.Error("Expected: https://egress.internal/form3 with a 10s timeout", "Got:", client.scheme, client.host, client.pathPrefix, client.timeout)
+	}
+	if client.signer == nil || client.signer.algorithm != "ed25519" {
+		t.Error("Expected: ed25519 signer", "Got:", client.signer)
+	}
+}
+
+func Test_Profile_Options_Failure(t *testing.T) {
+	tests := map[string]Profile{
+		"scheme must be http or https":         {BaseURL: "ftp://api.form3.tech"},
+		"host is missing":                      {BaseURL: "https://"},
+		"must not have a query":                {BaseURL: "https://api.form3.tech/?debug=true"},
+		"is not a UUID":                        {OrganisationID: "acme"},
+		"without signing_key_path":             {SigningKeyID: "75a8ba12-fff2-4a52-ad8a-e8b34c5ccec8"},
+		"without signing_key_id":               {SigningKeyPath: "key.pem"},
+		"no such file":                         {SigningKeyID: "75a8ba12-fff2-4a52-ad8a-e8b34c5ccec8", SigningKeyPath: "missing.pem"},
+		"is negative":                          {Timeout: -time.Second},
+		"max_attempts must be at least 1":      {Retry: &RetryPolicy{}},
+		"api_versions":                         {APIVersions: map[string]string{"organisation/accounts": "v2"}},
+		"without client_key":                   {ClientCert: "client.pem"},
+		"CA bundle":                            {CABundle: "missing.pem"},
+		"scheme must be http, https or socks5": {Proxy: "ftp://proxy.internal"},
+		"profile \"staging\": base_url":        {BaseURL: "staging", Name: "staging"},
+	}
+	for expected, profile := range tests {
+		_, err := profile.Options()
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Error("Expected:", expected, "Got:", err)
+		}
+	}
+}
+
+func Test_ProfileFromEnv_Success(t *testing.T) {
+	environment := map[string]string{
+		EnvBaseURL:        "https://api.staging-form3.tech",
+		EnvOrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+		EnvTimeout:        "5s",
+	}
+	profile, err := ProfileFromEnv(func(key string) string { return environment[key] })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.BaseURL != "https://api.staging-form3.tech" || profile.OrganisationID != "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c" || profile.Timeout != 5*time.Second {
+		t.Error("Expected: the settings of the environment", "Got:", profile)
+	}
+	if profile.Token != "" || profile.SigningKeyPath != "" {
+		t.Error("Expected: unset variables left empty", "Got:", profile)
+	}
+}
+
+func Test_ProfileFromEnv_Failure(t *testing.T) {
+	_, err := ProfileFromEnv(func(key string) string {
+		if key == EnvTimeout {
+			return "30"
+		}
+		return ""
+	})
+	if err == nil || !strings.Contains(err.Error(), "is not a duration") {
+		t.Error("Expected: is not a duration", "Got:", err)
+	}
+}
+
+func Test_NewClientFromEnv_Success(t *testing.T) {
+	t.Setenv(EnvBaseURL, "https://egress.internal/form3/")
+	t.Setenv(EnvTimeout, "10s")
+
+	client, err := NewClientFromEnv(SetTimeout(20 * time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.host != "egress.internal" || client.pathPrefix != "/form3" || client.timeout != 20*time.Second {
+		t.Error("Expected: egress.internal/form3 with the 20s timeout of the option", "Got:", client.host, client.pathPrefix, client.timeout)
+	}
+}
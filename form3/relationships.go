@@ -0,0 +1,295 @@
+package form3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ResourceIdentifier identifies a related resource by its type and ID.
+type ResourceIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// RelationshipLinks -> represents the links of a relationship
+type RelationshipLinks struct {
+	Self    *string `json:"self,omitempty"`
+	Related *string `json:"related,omitempty"`
+}
+
+// Relationship -> represents a link from one resource to one or more related resources
+//
+// See https://api-docs.form3.tech/api.html?http#introduction-and-api-conventions-message-body-structure
+//
+// When the resource was fetched WithInclude, the related resources returned alongside it
+// can be decoded with Decode without another request. FetchRelated falls back to fetching them.
+type Relationship struct {
+	Data  []ResourceIdentifier `json:"data"`
+	Links *RelationshipLinks   `json:"links,omitempty"`
+
+	included *Included // resources included in the response the relationship was decoded from
+	toOne    bool      // data was decoded from a single resource identifier, and is encoded back as one
+}
+
+// NewRelationship returns a relationship to a single resource.
+func NewRelationship(resourceType, id string) Relationship {
+	return Relationship{Data: []ResourceIdentifier{{Type: resourceType, ID: id}}}
+}
+
+// ID returns the ID of the first related resource, or "" if there is none.
+func (r Relationship) ID() string {
+	if len(r.Data) == 0 {
+		return ""
+	}
+	return r.Data[0].ID
+}
+
+// UnmarshalJSON accepts relationship data as a single resource identifier, an array of them, or null.
+func (r *Relationship) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Data  json.RawMessage    `json:"data"`
+		Links *RelationshipLinks `json:"links,omitempty"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	r.Links = raw.Links
+	r.Data = nil
+	r.toOne = false
+
+	data := bytes.TrimSpace(raw.Data)
+	switch {
+	case len(data) == 0 || bytes.Equal(data, []byte("null")):
+		return nil
+	case data[0] == '{':
+		var id ResourceIdentifier
+		if err := json.Unmarshal(data, &id); err != nil {
+			return err
+		}
+		r.Data = []ResourceIdentifier{id}
+		r.toOne = true
+		return nil
+	}
+	return json.Unmarshal(data, &r.Data)
+}
+
+// MarshalJSON encodes data in the shape it was decoded from, so that resources round trip unchanged:
+// a single resource identifier if it was one, an array otherwise.
+func (r Relationship) MarshalJSON() ([]byte, error) {
+	var data interface{} = r.Data
+	if r.toOne && len(r.Data) == 1 {
+		data = r.Data[0]
+	}
+
+	return json.Marshal(struct {
+		Data  interface{}        `json:"data"`
+		Links *RelationshipLinks `json:"links,omitempty"`
+	}{data, r.Links})
+}
+
+// Included reports whether every related resource was included in the response.
+func (r Relationship) Included() bool {
+	if r.included == nil || len(r.Data) == 0 {
+		return false
+	}
+	for _, id := range r.Data {
+		if _, ok := r.included.resources[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Decode decodes the included related resource(s) into v: a pointer to a struct for a single resource
+// or a pointer to a slice for many. It returns ErrNotIncluded if any of them were not included.
+func (r Relationship) Decode(v interface{}) error {
+	if !r.Included() {
+		return ErrNotIncluded
+	}
+
+	if len(r.Data) == 1 && !isSlicePointer(v) {
+		return json.Unmarshal(r.included.resources[r.Data[0]], v)
+	}
+
+	raw := make([]json.RawMessage, len(r.Data))
+	for i, id := range r.Data {
+		raw[i] = r.included.resources[id]
+	}
+	joined, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(joined, v)
+}
+
+// ErrNotIncluded is returned by Relationship.Decode when a related resource was not included in the response.
+var ErrNotIncluded = errors.New("related resource was not included in the response")
+
+// Included indexes the resources returned in the "included" member of a response.
+type Included struct {
+	resources map[ResourceIdentifier]json.RawMessage
+}
+
+func newIncluded(raw []json.RawMessage) (*Included, error) {
+	inc := &Included{resources: make(map[ResourceIdentifier]json.RawMessage, len(raw))}
+	for _, resource := range raw {
+		var id ResourceIdentifier
+		if err := json.Unmarshal(resource, &id); err != nil {
+			return nil, err
+		}
+		inc.resources[id] = resource
+	}
+	return inc, nil
+}
+
+// includedResolver is implemented by resources with relationships that can be resolved against included resources.
+type includedResolver interface {
+	resolveIncluded(included *Included)
+}
+
+// resolveRelationships attaches included resources to every relationship in relationships.
+func resolveRelationships(relationships map[string]Relationship, included *Included) {
+	for name, rel := range relationships {
+		rel.included = included
+		relationships[name] = rel
+	}
+}
+
+// WithInclude asks Form3 to include the named related resources in the response, e.g. WithInclude("master_account").
+func WithInclude(relationships ...string) RequestOption {
+	return func(opt *MakeRequestOptions) {
+		if opt.Params == nil {
+			opt.Params = map[string][]string{}
+		}
+		opt.Params.Set("include", strings.Join(relationships, ","))
+	}
+}
+
+// relatedFetcher fetches a related resource through the typed service of its resource type.
+type relatedFetcher func(ctx context.Context, client *Client, id string) (interface{}, *http.Response, error)
+
+// relatedFetchers maps resource types to their typed service, so related resources are fetched and decoded
+// the same way as when fetched directly.
+var relatedFetchers = map[string]relatedFetcher{
+	"accounts": func(ctx context.Context, client *Client, id string) (interface{}, *http.Response, error) {
+		return client.Accounts().Fetch(ctx, id)
+	},
+	"bankids": func(ctx context.Context, client *Client, id string) (interface{}, *http.Response, error) {
+		return client.BankIDs().Fetch(ctx, id)
+	},
+	"bics": func(ctx context.Context, client *Client, id string) (interface{}, *http.Response, error) {
+		return client.Bics().Fetch(ctx, id)
+	},
+	"claims": func(ctx context.Context, client *Client, id string) (interface{}, *http.Response, error) {
+		return client.Claims().Fetch(ctx, id)
+	},
+	"limits": func(ctx context.Context, client *Client, id string) (interface{}, *http.Response, error) {
+		return client.Limits().Fetch(ctx, id)
+	},
+	"organisations": func(ctx context.Context, client *Client, id string) (interface{}, *http.Response, error) {
+		return client.Organisations().Fetch(ctx, id)
+	},
+	"reports": func(ctx context.Context, client *Client, id string) (interface{}, *http.Response, error) {
+		return client.Reports().Fetch(ctx, id)
+	},
+	"roles": func(ctx context.Context, client *Client, id string) (interface{}, *http.Response, error) {
+		return client.Security().FetchRole(ctx, id)
+	},
+	"subscriptions": func(ctx context.Context, client *Client, id string) (interface{}, *http.Response, error) {
+		return client.Subscriptions().Fetch(ctx, id)
+	},
+	"users": func(ctx context.Context, client *Client, id string) (interface{}, *http.Response, error) {
+		return client.Security().FetchUser(ctx, id)
+	},
+}
+
+var (
+	resourcePathsMu sync.RWMutex
+	// resourcePaths maps resource types this client has no service for to their path,
+	// so related resources of those types can be fetched with a ResourceService.
+	resourcePaths = map[string]string{
+		"payments": paymentsPath,
+	}
+)
+
+// RegisterResourcePath tells FetchRelated where resources of resourceType live,
+// e.g. RegisterResourcePath("payment_admissions", "/transaction/payments/admissions").
+// Types with a service in this package are always fetched through it.
+func RegisterResourcePath(resourceType, path string) {
+	resourcePathsMu.Lock()
+	defer resourcePathsMu.Unlock()
+
+	resourcePaths[resourceType] = path
+}
+
+func resourcePath(resourceType string) (string, bool) {
+	resourcePathsMu.RLock()
+	defer resourcePathsMu.RUnlock()
+
+	path, ok := resourcePaths[resourceType]
+	return path, ok
+}
+
+// FetchRelated returns the resources a relationship points to.
+// Included resources are decoded directly; the others are fetched through the service of their type, e.g. Accounts(),
+// which T must match (FetchRelated[Account] for "accounts"). Types without a service, e.g. "payments",
+// are fetched from their registered path into any T.
+func FetchRelated[T any](ctx context.Context, client *Client, rel Relationship) ([]T, *http.Response, error) {
+	var res *http.Response
+
+	related := make([]T, 0, len(rel.Data))
+	for _, id := range rel.Data {
+		if rel.included != nil {
+			if raw, ok := rel.included.resources[id]; ok {
+				var v T
+				if err := json.Unmarshal(raw, &v); err != nil {
+					return nil, res, err
+				}
+				related = append(related, v)
+				continue
+			}
+		}
+
+		v, r, err := fetchRelated[T](ctx, client, id)
+		res = r
+		if err != nil {
+			return nil, res, err
+		}
+		related = append(related, *v)
+	}
+
+	return related, res, nil
+}
+
+func fetchRelated[T any](ctx context.Context, client *Client, id ResourceIdentifier) (*T, *http.Response, error) {
+	if fetch, ok := relatedFetchers[id.Type]; ok {
+		v, res, err := fetch(ctx, client, id.ID)
+		if err != nil {
+			return nil, res, err
+		}
+		typed, ok := v.(*T)
+		if !ok {
+			return nil, res, fmt.Errorf("related %s are fetched as %T, not %T", id.Type, v, typed)
+		}
+		return typed, res, nil
+	}
+
+	path, ok := resourcePath(id.Type)
+	if !ok {
+		return nil, nil, fmt.Errorf("no path registered for resource type %q", id.Type)
+	}
+	return NewResourceService[T](client, path).Fetch(ctx, id.ID)
+}
+
+func isSlicePointer(v interface{}) bool {
+	t := reflect.TypeOf(v)
+	return t != nil && t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Slice
+}
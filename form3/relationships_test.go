@@ -0,0 +1,241 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_FetchAccountWithInclude_Success(t *testing.T) {
+	srv := serverMock("/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("include") != "master_account" {
+			t.Error("Expected: master_account", "Got:", r.URL.Query().Get("include"))
+		}
+		w.Write([]byte(accountWithIncludedJSON))
+	})
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	account, _, err := client.Accounts().Fetch(context.Background(), "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", WithInclude("master_account"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rel := account.Related("master_account")
+	if rel.ID() != "158f775c-4ecd-4861-b33d-30df9a29de78" {
+		t.Error("Expected: 158f775c-4ecd-4861-b33d-30df9a29de78", "Got:", rel.ID())
+	}
+
+	var master Account
+	if err := rel.Decode(&master); err != nil {
+		t.Fatal(err)
+	}
+	if master.Attributes.BankID != "400300" {
+		t.Error("Expected: 400300", "Got:", master.Attributes.BankID)
+	}
+
+	var owner Account
+	if err := account.Related("owner").Decode(&owner); !errors.Is(err, ErrNotIncluded) {
+		t.Error("Expected:", ErrNotIncluded, "Got:", err)
+	}
+}
+
+func Test_FetchRelated_NotIncluded_Success(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(accountWithRelationshipJSON))
+	})
+	mux.HandleFunc("/v1/organisation/accounts/158f775c-4ecd-4861-b33d-30df9a29de78", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(accountJSON))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	account, _, err := client.Accounts().Fetch(context.Background(), "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if account.Related("master_account").Included() {
+		t.Error("Expected the master account not to be included")
+	}
+
+	masters, res, err := FetchRelated[Account](context.Background(), client, account.Related("master_account"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == nil || res.StatusCode != http.StatusOK {
+		t.Error("Expected the master account to be fetched, Got:", res)
+	}
+	if len(masters) != 1 || masters[0].ID == "" {
+		t.Error("Expected: 1 master account", "Got:", masters)
+	}
+}
+
+func Test_UnmarshalRelationship_Array_Success(t *testing.T) {
+	var rel Relationship
+	if err := rel.UnmarshalJSON([]byte(`{"data": [{"type": "accounts", "id": "a"}, {"type": "accounts", "id": "b"}]}`)); err != nil {
+		t.Fatal(err)
+	}
+	if len(rel.Data) != 2 || rel.Data[1].ID != "b" {
+		t.Error("Expected: 2 resource identifiers", "Got:", rel.Data)
+	}
+
+	if err := rel.UnmarshalJSON([]byte(`{"data": null}`)); err != nil {
+		t.Fatal(err)
+	}
+	if len(rel.Data) != 0 {
+		t.Error("Expected: no resource identifiers", "Got:", rel.Data)
+	}
+}
+
+func Test_MarshalRelationship_RoundTrip_Success(t *testing.T) {
+	for _, raw := range []string{
+		`{"data":{"type":"accounts","id":"b"}}`,
+		`{"data":[{"type":"accounts","id":"b"}]}`,
+		`{"data":[{"type":"accounts","id":"a"},{"type":"accounts","id":"b"}],"links":{"related":"/v1/organisation/accounts"}}`,
+		`{"data":null}`,
+	} {
+		var rel Relationship
+		if err := json.Unmarshal([]byte(raw), &rel); err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := json.Marshal(rel)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != raw {
+			t.Error("Expected:", raw, "Got:", string(b))
+		}
+	}
+}
+
+func Test_FetchRelated_ClaimPayment_Success(t *testing.T) {
+	type payment struct {
+		ID         string `json:"id"`
+		Attributes struct {
+			Amount Amount `json:"amount"`
+		} `json:"attributes"`
+	}
+
+	payments := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/transaction/claims/3f0c8a2e-5b1d-4c7e-9f2a-8d6b4e2c0a11", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("include") == "payment" {
+			w.Write([]byte(claimWithIncludedJSON))
+			return
+		}
+		w.Write([]byte(claimJSON))
+	})
+	mux.HandleFunc("/v1/transaction/payments/a8b6b5a2-0c1f-4d3e-9b7a-6f5e4d3c2b1a", func(w http.ResponseWriter, r *http.Request) {
+		payments++
+		w.Write([]byte(`{"data": {"type": "payments", "id": "a8b6b5a2-0c1f-4d3e-9b7a-6f5e4d3c2b1a", "attributes": {"amount": "100.21"}}}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	for _, options := range [][]RequestOption{{WithInclude("payment")}, nil} {
+		claim, _, err := client.Claims().Fetch(context.Background(), "3f0c8a2e-5b1d-4c7e-9f2a-8d6b4e2c0a11", options...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		related, _, err := FetchRelated[payment](context.Background(), client, claim.Relationships.Payment)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(related) != 1 || !related[0].Attributes.Amount.Equal(MustParseAmount("100.21")) {
+			t.Error("Expected: payment of 100.21", "Got:", related)
+		}
+	}
+
+	// Fetched only when not included
+	if payments != 1 {
+		t.Error("Expected: 1 payment fetched", "Got:", payments)
+	}
+}
+
+func Test_FetchRelated_WrongType_Failure(t *testing.T) {
+	client, srv := testClient("/v1/organisation/accounts/158f775c-4ecd-4861-b33d-30df9a29de78", http.StatusOK, accountJSON)
+	defer srv.Close()
+
+	// Accounts are fetched through the accounts service, so they cannot be decoded into another type
+	if _, _, err := FetchRelated[Organisation](context.Background(), client, NewRelationship("accounts", "158f775c-4ecd-4861-b33d-30df9a29de78")); err == nil {
+		t.Error("Expected: error", "Got: nil")
+	}
+}
+
+var claimWithIncludedJSON = `{
+	"data": {
+		"type": "claims",
+		"id": "3f0c8a2e-5b1d-4c7e-9f2a-8d6b4e2c0a11",
+		"organisation_id": "158f775d-4ecd-4861-b33d-30df9a29de78",
+		"attributes": {"reason": "non_receipt", "amount": "100.21", "currency": "GBP"},
+		"relationships": {
+			"payment": {"data": {"type": "payments", "id": "a8b6b5a2-0c1f-4d3e-9b7a-6f5e4d3c2b1a"}}
+		}
+	},
+	"included": [
+		{"type": "payments", "id": "a8b6b5a2-0c1f-4d3e-9b7a-6f5e4d3c2b1a", "attributes": {"amount": "100.21"}}
+	]
+}`
+
+var accountWithRelationshipJSON = `{
+	"data": {
+		"type": "accounts",
+		"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+		"organisation_id": "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+		"version": 0,
+		"attributes": {
+			"country": "GB",
+			"bank_id": "400300",
+			"bank_id_code": "GBDSC"
+		},
+		"relationships": {
+			"master_account": {
+				"data": [{"type": "accounts", "id": "158f775c-4ecd-4861-b33d-30df9a29de78"}]
+			}
+		}
+	}
+}`
+
+var accountWithIncludedJSON = `{
+	"data": {
+		"type": "accounts",
+		"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+		"organisation_id": "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+		"version": 0,
+		"attributes": {
+			"country": "GB",
+			"bank_id": "400300",
+			"bank_id_code": "GBDSC"
+		},
+		"relationships": {
+			"master_account": {
+				"data": {"type": "accounts", "id": "158f775c-4ecd-4861-b33d-30df9a29de78"}
+			},
+			"owner": {
+				"data": {"type": "users", "id": "5e6ea5a0-47e3-43b7-a6cf-3bdf1e4e0b43"}
+			}
+		}
+	},
+	"included": [
+		{
+			"type": "accounts",
+			"id": "158f775c-4ecd-4861-b33d-30df9a29de78",
+			"organisation_id": "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+			"version": 0,
+			"attributes": {
+				"country": "GB",
+				"bank_id": "400300",
+				"bank_id_code": "GBDSC"
+			}
+		}
+	]
+}`
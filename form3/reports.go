@@ -0,0 +1,275 @@
+package form3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	reportsPath string = "/reports"
+
+	defaultReportPollInterval time.Duration = 5 * time.Second
+	defaultDownloadAttempts   int           = 3
+)
+
+// Report types.
+const (
+	ReportTypeSettlement   string = "settlement"
+	ReportTypeTransactions string = "transactions"
+)
+
+// Report formats.
+const (
+	ReportFormatCSV  string = "csv"
+	ReportFormatJSON string = "json"
+)
+
+// Report statuses.
+const (
+	ReportStatusPending string = "pending"
+	ReportStatusReady   string = "ready"
+	ReportStatusFailed  string = "failed"
+)
+
+// ErrReportFailed is returned when Form3 fails to generate a report.
+var ErrReportFailed = errors.New("report generation failed")
+
+// Report represents a report that Form3 generates asynchronously.
+type Report struct {
+	Attributes     ReportAttributes `json:"attributes"`
+	ID             string           `json:"id"`
+	OrganisationID string           `json:"organisation_id"`
+	Type           string           `json:"type"`
+	Version        int              `json:"version"`
+}
+
+// ReportAttributes represents attributes of a Report
+type ReportAttributes struct {
+	ReportType string `json:"report_type"`
+	Format     string `json:"format"`
+	// From and To are ISO 8601 dates (YYYY-MM-DD) bounding the reporting period.
+	From          string `json:"from"`
+	To            string `json:"to"`
+	Status        string `json:"status,omitempty"`
+	StatusReason  string `json:"status_reason,omitempty"`
+	ContentLength int64  `json:"content_length,omitempty"`
+}
+
+// ReportsService implements a service to generate and download reports
+type ReportsService struct {
+	client       *Client
+	pollInterval time.Duration
+	attempts     int
+}
+
+// NewReportsService creates a new ReportsService.
+func NewReportsService(client *Client) *ReportsService {
+	return &ReportsService{
+		client:       client,
+		pollInterval: defaultReportPollInterval,
+		attempts:     defaultDownloadAttempts,
+	}
+}
+
+type fetchReportAPIResponse struct {
+	Data  Report `json:"data"`
+	Links Links  `json:"links"`
+}
+
+type createReportAPIPayload struct {
+	Data Report `json:"data"`
+}
+
+// Request -> Ask Form3 to generate a report. The report is generated asynchronously; use Wait to poll until it is ready.
+//
+// POST /v1/reports
+func (s *ReportsService) Request(ctx context.Context, report *Report) (*Report, *http.Response, error) {
+	data := &createReportAPIPayload{Data: *report}
+	if data.Data.OrganisationID == "" {
+		data.Data.OrganisationID = s.client.organisationID
+	}
+
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "POST",
+		Path:   reportsPath,
+		Body:   data,
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret fetchReportAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	return &ret.Data, res, nil
+}
+
+// Fetch -> Get a single report, including its generation status, using the report ID.
+//
+// GET /v1/reports/{report_id}
+func (s *ReportsService) Fetch(ctx context.Context, id string) (*Report, *http.Response, error) {
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%s", reportsPath, id),
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret fetchReportAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	return &ret.Data, res, nil
+}
+
+// Wait -> Poll the report every PollInterval until it is ready, it fails or ctx is done.
+// The returned error wraps ErrReportFailed if Form3 could not generate the report.
+func (s *ReportsService) Wait(ctx context.Context, id string) (*Report, error) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		report, _, err := s.Fetch(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		switch report.Attributes.Status {
+		case ReportStatusReady:
+			return report, nil
+		case ReportStatusFailed:
+			return report, fmt.Errorf("%w: %s", ErrReportFailed, report.Attributes.StatusReason)
+		}
+
+		select {
+		case <-ctx.Done():
+			return report, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Download -> Stream the content of a ready report, in the report's format, to w without buffering it in memory.
+// It returns the number of bytes written.
+//
+// GET /v1/reports/{report_id}/content
+//
+// If the connection drops part way through and the server accepts Range requests,
+// the download is resumed from the last byte written (up to Attempts times in total).
+func (s *ReportsService) Download(ctx context.Context, report *Report, w io.Writer) (int64, *http.Response, error) {
+	id, format := report.ID, report.Attributes.Format
+
+	var written int64
+	for attempt := 1; ; attempt++ {
+		res, resumable, err := s.downloadFrom(ctx, id, format, w, &written)
+		if err == nil {
+			return written, res, nil
+		}
+
+		var readErr *downloadReadError
+		if !errors.As(err, &readErr) || !resumable || attempt >= s.attempts || ctx.Err() != nil {
+			return written, res, err
+		}
+
+		s.client.infof("GET -> report %s -> resuming download at byte %d: %s", id, written, readErr.err)
+	}
+}
+
+// PollInterval -> how often Wait checks whether a report is ready. Defaults to 5s, which intervals of 0 or less restore.
+func (s *ReportsService) PollInterval(interval time.Duration) ReportsAPI {
+	if interval <= 0 {
+		interval = defaultReportPollInterval
+	}
+	s.pollInterval = interval
+	return s
+}
+
+// Attempts -> how many times Download connects before giving up on an interrupted download. Defaults to 3.
+func (s *ReportsService) Attempts(attempts int) ReportsAPI {
+	s.attempts = attempts
+	return s
+}
+
+// downloadReadError is an error reading the response body, as opposed to writing to the destination.
+type downloadReadError struct {
+	err error
+}
+
+func (e *downloadReadError) Error() string { return e.err.Error() }
+func (e *downloadReadError) Unwrap() error { return e.err }
+
+// downloadFrom requests the report content following the bytes already written and copies it to w, counting as it goes.
+// It reports whether the server accepts Range requests so the download can be resumed.
+func (s *ReportsService) downloadFrom(ctx context.Context, id, format string, w io.Writer, written *int64) (*http.Response, bool, error) {
+	offset := *written
+
+	header := http.Header{}
+	header.Set("Accept", reportMediaType(format))
+	if offset > 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%s/content", reportsPath, id),
+		Header: header,
+	})
+	if err != nil {
+		return res, false, err
+	}
+	defer res.Body.Close()
+
+	resumable := res.Header.Get("Accept-Ranges") == "bytes" || res.StatusCode == http.StatusPartialContent
+
+	body := io.Reader(res.Body)
+	if offset > 0 && res.StatusCode != http.StatusPartialContent {
+		// The server ignored the Range header and is sending the whole report again; skip what we already have.
+		if _, err := io.CopyN(io.Discard, body, offset); err != nil {
+			return res, resumable, &downloadReadError{err}
+		}
+	}
+
+	// A body cut short of its Content-Length surfaces as io.ErrUnexpectedEOF from the transport.
+	_, err = io.Copy(&countingWriter{w: w, n: written}, &readErrorReader{r: body})
+	return res, resumable, err
+}
+
+func reportMediaType(format string) string {
+	if format == ReportFormatJSON {
+		return "application/json"
+	}
+	return "text/csv"
+}
+
+// countingWriter counts bytes successfully written to w.
+type countingWriter struct {
+	w io.Writer
+	n *int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	*c.n += int64(n)
+	return n, err
+}
+
+// readErrorReader marks errors from r as read errors so they can be told apart from write errors.
+type readErrorReader struct {
+	r io.Reader
+}
+
+func (r *readErrorReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if err != nil && err != io.EOF {
+		err = &downloadReadError{err}
+	}
+	return n, err
+}
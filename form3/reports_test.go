@@ -0,0 +1,122 @@
+package form3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_WaitForReport_Success(t *testing.T) {
+	polls := 0
+	srv := serverMock("/v1/reports/7c1f0a52-3c4e-4b71-9a0e-5d2f8b6e4c10", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		status := ReportStatusPending
+		if polls == 3 {
+			status = ReportStatusReady
+		}
+		fmt.Fprintf(w, `{"data": {"id": "7c1f0a52-3c4e-4b71-9a0e-5d2f8b6e4c10", "attributes": {"format": "csv", "status": %q}}}`, status)
+	})
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	report, err := client.Reports().PollInterval(time.Millisecond).Wait(context.Background(), "7c1f0a52-3c4e-4b71-9a0e-5d2f8b6e4c10")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if report.Attributes.Status != ReportStatusReady {
+		t.Error("Expected:", ReportStatusReady, "Got:", report.Attributes.Status)
+	}
+
+	if polls != 3 {
+		t.Error("Expected: 3 polls", "Got:", polls)
+	}
+}
+
+func Test_WaitForReport_NonPositiveInterval_Success(t *testing.T) {
+	client, srv := testClient("/v1/reports/7c1f0a52-3c4e-4b71-9a0e-5d2f8b6e4c10", http.StatusOK,
+		`{"data": {"id": "7c1f0a52-3c4e-4b71-9a0e-5d2f8b6e4c10", "attributes": {"format": "csv", "status": "ready"}}}`)
+	defer srv.Close()
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		reports := client.Reports().PollInterval(interval)
+		if polled := reports.(*ReportsService).pollInterval; polled != defaultReportPollInterval {
+			t.Error("Expected:", defaultReportPollInterval, "Got:", polled)
+		}
+
+		if _, err := reports.Wait(context.Background(), "7c1f0a52-3c4e-4b71-9a0e-5d2f8b6e4c10"); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func Test_DownloadReport_Resumed_Success(t *testing.T) {
+	content := strings.Repeat("2020-06-30,GBP,100.21\n", 100)
+
+	var ranges []string
+	srv := serverMock("/v1/reports/7c1f0a52-3c4e-4b71-9a0e-5d2f8b6e4c10/content", func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		w.Header().Set("Accept-Ranges", "bytes")
+		w.Header().Set("Content-Type", r.Header.Get("Accept"))
+
+		if r.Header.Get("Range") == "" {
+			// Promise the whole report but drop the connection half way through.
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(content[:len(content)/2]))
+			return
+		}
+
+		var offset int
+		fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-", &offset)
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, len(content)-1, len(content)))
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write([]byte(content[offset:]))
+	})
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	var buf bytes.Buffer
+	report := &Report{ID: "7c1f0a52-3c4e-4b71-9a0e-5d2f8b6e4c10", Attributes: ReportAttributes{Format: ReportFormatCSV}}
+
+	n, res, err := client.Reports().Download(context.Background(), report, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if http.StatusPartialContent != res.StatusCode {
+		t.Error("Expected:", http.StatusPartialContent, "Got:", res.StatusCode)
+	}
+
+	if n != int64(len(content)) || buf.String() != content {
+		t.Error("Expected:", len(content), "bytes", "Got:", n)
+	}
+
+	expected := fmt.Sprintf("bytes=%d-", len(content)/2)
+	if len(ranges) != 2 || ranges[1] != expected {
+		t.Error("Expected: resume with", expected, "Got:", ranges)
+	}
+}
+
+func Test_DownloadReport_NotResumable_Failure(t *testing.T) {
+	srv := serverMock("/v1/reports/7c1f0a52-3c4e-4b71-9a0e-5d2f8b6e4c10/content", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "100")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("partial"))
+	})
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	var buf bytes.Buffer
+	report := &Report{ID: "7c1f0a52-3c4e-4b71-9a0e-5d2f8b6e4c10", Attributes: ReportAttributes{Format: ReportFormatJSON}}
+
+	_, _, err := client.Reports().Download(context.Background(), report, &buf)
+	if err == nil {
+		t.Error("Expected: error", "Got: nil")
+	}
+}
@@ -0,0 +1,245 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DataEnvelope is the body of requests and responses carrying a single resource.
+// Links and Included are only present in responses.
+type DataEnvelope[T any] struct {
+	Data     T                 `json:"data"`
+	Included []json.RawMessage `json:"included,omitempty"`
+	Links    *Links            `json:"links,omitempty"`
+}
+
+// ListEnvelope is the body of responses carrying a page of resources.
+type ListEnvelope[T any] struct {
+	Data     []T               `json:"data"`
+	Included []json.RawMessage `json:"included,omitempty"`
+	Links    Links             `json:"links"`
+}
+
+// ResourceService implements fetch, list, create, update and delete for any Form3 resource type T
+// that follows the API conventions (data envelopes, page[number]/page[size] pagination, filter[...] params and versioned deletes).
+//
+// It is the building block for the services in this package and can be used directly for endpoints the client does not wrap yet:
+//
+//	payments := form3.NewResourceService[Payment](client, "/transaction/payments")
+//	payment, _, err := payments.Fetch(ctx, id)
+type ResourceService[T any] struct {
+	client     *Client
+	path       string
+	pagination Pagination
+	filter     url.Values
+}
+
+// NewResourceService creates a new ResourceService for resources at path (relative to the API version, e.g. "/organisation/accounts").
+func NewResourceService[T any](client *Client, path string) *ResourceService[T] {
+	return &ResourceService[T]{
+		client:     client,
+		path:       path,
+		pagination: NewPagination(),
+		filter:     url.Values{},
+	}
+}
+
+// Fetch -> Get a single resource using its ID.
+//
+// GET /v1/{path}/{id}
+//
+// Pass WithInclude to have related resources returned alongside it; they are attached to the resource's relationships.
+func (s *ResourceService[T]) Fetch(ctx context.Context, id string, options ...RequestOption) (*T, *http.Response, error) {
+	opt := MakeRequestOptions{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%s", s.path, id),
+	}
+	for _, option := range options {
+		option(&opt)
+	}
+
+	res, err := s.client.MakeRequest(ctx, opt)
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret DataEnvelope[T]
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	if err := resolveIncluded(ret.Included, &ret.Data); err != nil {
+		return nil, res, err
+	}
+
+	return &ret.Data, res, nil
+}
+
+// List -> List a page of resources matching the filters, paged using Number and Size.
+//
+// GET /v1/{path}?page[number]={page_number}&page[size]={page_size}&filter[{attribute}]={filter_value}
+func (s *ResourceService[T]) List(ctx context.Context, options ...RequestOption) ([]T, *http.Response, error) {
+	ret, res, err := s.list(ctx, s.pagination, options...)
+	if err != nil {
+		return nil, res, err
+	}
+	return ret.Data, res, nil
+}
+
+// ListAll -> List every resource matching the filters, following all pages from Number onwards.
+func (s *ResourceService[T]) ListAll(ctx context.Context, options ...RequestOption) ([]T, *http.Response, error) {
+	var all []T
+
+	page := s.pagination
+	for {
+		ret, res, err := s.list(ctx, page, options...)
+		if err != nil {
+			return nil, res, err
+		}
+		all = append(all, ret.Data...)
+
+		if lastPage(len(ret.Data), ret.Links) {
+			return all, res, nil
+		}
+		page.Number++
+	}
+}
+
+// Create -> Create a new resource.
+//
+// POST /v1/{path}
+func (s *ResourceService[T]) Create(ctx context.Context, resource *T) (*T, *http.Response, error) {
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "POST",
+		Path:   s.path,
+		Body:   &DataEnvelope[T]{Data: *resource},
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret DataEnvelope[T]
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	return &ret.Data, res, nil
+}
+
+// Update -> Update an existing resource. The resource must carry its current version.
+//
+// PATCH /v1/{path}/{id}
+func (s *ResourceService[T]) Update(ctx context.Context, id string, resource *T) (*T, *http.Response, error) {
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "PATCH",
+		Path:   fmt.Sprintf("%s/%s", s.path, id),
+		Body:   &DataEnvelope[T]{Data: *resource},
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret DataEnvelope[T]
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	return &ret.Data, res, nil
+}
+
+// Delete -> Delete a resource at the given version.
+//
+// DELETE /v1/{path}/{id}?version={version}
+//
+// No response body returned.
+// Potential status codes:
+// - 204	No Content	Resource has been successfully deleted
+// - 404	Not Found	Specified resource does not exist
+// - 409	Conflict	Specified version incorrect
+func (s *ResourceService[T]) Delete(ctx context.Context, id string, version int) (bool, *http.Response, error) {
+	params := url.Values{}
+	params.Add("version", strconv.Itoa(version))
+
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("%s/%s", s.path, id),
+		Params: params,
+	})
+	if err != nil {
+		return false, res, err
+	}
+
+	return true, res, nil
+}
+
+// Number -> page number requested. Defaults to 0.
+func (s *ResourceService[T]) Number(number int) *ResourceService[T] {
+	s.pagination.Number = number
+	return s
+}
+
+// Size -> size is the max number of resources to return. Defaults to 10.
+func (s *ResourceService[T]) Size(size int) *ResourceService[T] {
+	s.pagination.Size = size
+	return s
+}
+
+// Filter -> only list resources whose attribute matches one of values, e.g. Filter("country", "GB", "FR").
+// Combinations of filters act as AND expressions.
+func (s *ResourceService[T]) Filter(attribute string, values ...string) *ResourceService[T] {
+	s.filter.Set(fmt.Sprintf("filter[%s]", attribute), strings.Join(values, ","))
+	return s
+}
+
+func (s *ResourceService[T]) list(ctx context.Context, page Pagination, options ...RequestOption) (*ListEnvelope[T], *http.Response, error) {
+	params := page.Params()
+	for key, values := range s.filter {
+		params[key] = values
+	}
+
+	opt := MakeRequestOptions{
+		Method: "GET",
+		Path:   s.path,
+		Params: params,
+	}
+	for _, option := range options {
+		option(&opt)
+	}
+
+	res, err := s.client.MakeRequest(ctx, opt)
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret ListEnvelope[T]
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	for i := range ret.Data {
+		if err := resolveIncluded(ret.Included, &ret.Data[i]); err != nil {
+			return nil, res, err
+		}
+	}
+	return &ret, res, nil
+}
+
+// resolveIncluded attaches included resources to the relationships of resource, if it has any.
+func resolveIncluded(raw []json.RawMessage, resource interface{}) error {
+	resolver, ok := resource.(includedResolver)
+	if !ok || len(raw) == 0 {
+		return nil
+	}
+
+	included, err := newIncluded(raw)
+	if err != nil {
+		return err
+	}
+	resolver.resolveIncluded(included)
+	return nil
+}
@@ -0,0 +1,94 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+type widget struct {
+	ID      string `json:"id"`
+	Version int    `json:"version"`
+	Name    string `json:"name"`
+}
+
+func Test_ResourceService_ListAll_Filter(t *testing.T) {
+	srv := serverMock("/v1/widgets", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("filter[country]") != "GB,FR" {
+			t.Error("Expected: filter[country]=GB,FR", "Got:", r.URL.RawQuery)
+		}
+
+		switch r.URL.Query().Get("page[number]") {
+		case "0":
+			fmt.Fprint(w, `{"data": [{"id": "w1"}, {"id": "w2"}]}`)
+		default:
+			fmt.Fprint(w, `{"data": []}`)
+		}
+	})
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	widgets, _, err := NewResourceService[widget](client, "/widgets").Filter("country", "GB", "FR").Size(2).ListAll(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(widgets) != 2 {
+		t.Error("Expected:", 2, "Got:", len(widgets))
+	}
+}
+
+// cappedPage returns the bounds of the page requested by r in a list of total resources, with at most max per page
+// whatever the size asked for, and its links, as the API does.
+func cappedPage(r *http.Request, total, max int) (int, int, Links) {
+	size, _ := strconv.Atoi(r.URL.Query().Get("page[size]"))
+	if size > max {
+		size = max
+	}
+	number, _ := strconv.Atoi(r.URL.Query().Get("page[number]"))
+
+	start, end := number*size, (number+1)*size
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+
+	self := r.URL.String()
+	links := Links{Self: &self}
+	if end < total {
+		next := fmt.Sprintf("%s?page[number]=%d&page[size]=%d", r.URL.Path, number+1, size)
+		links.Next = &next
+	}
+	return start, end, links
+}
+
+func Test_ResourceService_Update(t *testing.T) {
+	var method string
+	var payload DataEnvelope[widget]
+	srv := serverMock("/v1/widgets/w1", func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		json.NewDecoder(r.Body).Decode(&payload)
+		payload.Data.Version++
+		json.NewEncoder(w).Encode(payload)
+	})
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	updated, _, err := NewResourceService[widget](client, "/widgets").Update(context.Background(), "w1", &widget{ID: "w1", Version: 3, Name: "renamed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if method != "PATCH" {
+		t.Error("Expected: PATCH", "Got:", method)
+	}
+
+	if updated.Version != 4 || updated.Name != "renamed" {
+		t.Error("Expected: version 4 renamed", "Got:", updated)
+	}
+}
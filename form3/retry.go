@@ -0,0 +1,82 @@
+package form3
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy decides how requests that failed transiently are retried, see SetRetryPolicy.
+type RetryPolicy struct {
+	MaxAttempts int           `yaml:"max_attempts"` // attempts in total, 1 disables retries
+	MinBackoff  time.Duration `yaml:"min_backoff"`  // wait before the first retry, doubled for every further one
+	MaxBackoff  time.Duration `yaml:"max_backoff"`  // longest wait between attempts, unless the API asks for more with Retry-After
+}
+
+// defaultRetryPolicy makes a single attempt, as the client always did.
+var defaultRetryPolicy = RetryPolicy{MaxAttempts: 1}
+
+// SetRetryPolicy retries requests failing with a network error or a 502, 503 or 504 status when their method is
+// idempotent (GET, HEAD, OPTIONS, PUT and DELETE), and any request throttled with a 429 status.
+// Waits follow a Retry-After header when there is one. Requests are not retried by default.
+func SetRetryPolicy(policy RetryPolicy) ClientOptionFunc {
+	return func(c *Client) error {
+		if err := policy.validate(); err != nil {
+			return err
+		}
+		c.retryPolicy = policy
+		return nil
+	}
+}
+
+func (p RetryPolicy) validate() error {
+	switch {
+	case p.MaxAttempts < 1:
+		return errors.New("retry max_attempts must be at least 1")
+	case p.MinBackoff < 0 || p.MaxBackoff < 0:
+		return errors.New("retry backoffs must not be negative")
+	case p.MaxBackoff > 0 && p.MinBackoff > p.MaxBackoff:
+		return errors.New("retry min_backoff must not be greater than max_backoff")
+	}
+	return nil
+}
+
+// retry reports whether the attempt (counting from 1) that got res and err should be retried, and after how long.
+func (p RetryPolicy) retry(attempt int, method string, res *http.Response, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	switch {
+	case res != nil && res.StatusCode == http.StatusTooManyRequests:
+	case isIdempotent(method) && (err != nil || isTransient(res.StatusCode)):
+	default:
+		return 0, false
+	}
+
+	backoff := p.MinBackoff << (attempt - 1)
+	if p.MaxBackoff > 0 && (backoff > p.MaxBackoff || backoff < 0) {
+		backoff = p.MaxBackoff
+	}
+
+	// The server knows best when it can take the request again
+	if res != nil {
+		if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			backoff = time.Duration(seconds) * time.Second
+		}
+	}
+	return backoff, true
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func isTransient(status int) bool {
+	return status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+}
@@ -0,0 +1,75 @@
+package form3
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_Retry_Transient_Success(t *testing.T) {
+	attempts := 0
+	srv := serverMock("/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(accountJSON))
+	})
+	defer srv.Close()
+
+	client := testClientFor(srv)
+	SetRetryPolicy(RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond})(client)
+
+	_, _, err := client.Accounts().Fetch(context.Background(), "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 3 {
+		t.Error("Expected:", 3, "Got:", attempts)
+	}
+}
+
+func Test_Retry_NotIdempotent_Failure(t *testing.T) {
+	attempts := 0
+	srv := serverMock("/v1/organisation/accounts", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer srv.Close()
+
+	client := testClientFor(srv)
+	SetRetryPolicy(RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond})(client)
+
+	_, _, err := client.Accounts().Create(context.Background(), &Account{ID: "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"})
+	if err == nil {
+		t.Fatal("Expected: error", "Got:", nil)
+	}
+	if attempts != 1 {
+		t.Error("Expected:", 1, "Got:", attempts)
+	}
+}
+
+func Test_RetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, MinBackoff: 100 * time.Millisecond, MaxBackoff: 500 * time.Millisecond}
+
+	for attempt, expected := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 4: 500 * time.Millisecond} {
+		backoff, ok := policy.retry(attempt, http.MethodGet, &http.Response{StatusCode: http.StatusBadGateway}, nil)
+		if !ok || backoff != expected {
+			t.Error("Expected:", expected, "Got:", backoff, ok)
+		}
+	}
+
+	throttled := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"1"}}}
+	if backoff, ok := policy.retry(1, http.MethodPost, throttled, nil); !ok || backoff != time.Second {
+		t.Error("Expected:", time.Second, "Got:", backoff, ok)
+	}
+
+	if _, ok := policy.retry(5, http.MethodGet, &http.Response{StatusCode: http.StatusBadGateway}, nil); ok {
+		t.Error("Expected: no retry after the last attempt")
+	}
+	if _, ok := policy.retry(1, http.MethodGet, &http.Response{StatusCode: http.StatusNotFound}, nil); ok {
+		t.Error("Expected: no retry of a 404")
+	}
+}
@@ -0,0 +1,231 @@
+package form3
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	routingPath string = "/routing/lookups"
+
+	defaultRoutingCacheTTL time.Duration = 10 * time.Minute
+	maxRoutingCacheEntries int           = 10000
+)
+
+// Payment schemes.
+const (
+	SchemeFPS         string = "FPS"
+	SchemeBacs        string = "Bacs"
+	SchemeCHAPS       string = "CHAPS"
+	SchemeSEPA        string = "SEPA"
+	SchemeSEPAInstant string = "SEPAINSTANT"
+)
+
+// Route represents how payments to an account identified by bank ID, bank ID code and/or BIC can be routed.
+type Route struct {
+	Attributes RouteAttributes `json:"attributes"`
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+}
+
+// RouteAttributes represents attributes of a Route
+type RouteAttributes struct {
+	BankID     string        `json:"bank_id,omitempty"`
+	BankIDCode string        `json:"bank_id_code,omitempty"`
+	Bic        string        `json:"bic,omitempty"`
+	Country    string        `json:"country,omitempty"`
+	Schemes    []SchemeRoute `json:"schemes"`
+}
+
+// SchemeRoute describes whether a destination can be reached through a scheme, and which gateway handles it.
+type SchemeRoute struct {
+	Scheme    string `json:"scheme"`
+	Gateway   string `json:"gateway,omitempty"`
+	Reachable bool   `json:"reachable"`
+}
+
+// Reachable reports whether the destination can be reached through scheme.
+func (r *Route) Reachable(scheme string) bool {
+	for _, s := range r.Attributes.Schemes {
+		if s.Scheme == scheme && s.Reachable {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportedSchemes returns the schemes through which the destination can be reached.
+func (r *Route) SupportedSchemes() []string {
+	var schemes []string
+	for _, s := range r.Attributes.Schemes {
+		if s.Reachable {
+			schemes = append(schemes, s.Scheme)
+		}
+	}
+	return schemes
+}
+
+// Choose returns the first scheme in preference order through which the destination can be reached.
+// Order preference from cheapest to most expensive to pick the cheapest scheme, e.g. Choose(SchemeFPS, SchemeBacs, SchemeCHAPS).
+func (r *Route) Choose(preference ...string) (string, bool) {
+	for _, scheme := range preference {
+		if r.Reachable(scheme) {
+			return scheme, true
+		}
+	}
+	return "", false
+}
+
+// RoutingService implements a service to look up how, and whether, an account can be paid
+type RoutingService struct {
+	client *Client
+}
+
+// NewRoutingService creates a new RoutingService.
+func NewRoutingService(client *Client) *RoutingService {
+	return &RoutingService{
+		client: client,
+	}
+}
+
+type fetchRouteAPIResponse struct {
+	Data  Route `json:"data"`
+	Links Links `json:"links"`
+}
+
+// Lookup -> Find the schemes and gateways that can reach the account with the given BankID, BankIDCode and Bic.
+//
+// GET /v1/routing/lookups?filter[bank_id]={bank_id}&filter[bank_id_code]={bank_id_code}&filter[bic]={bic}
+//
+// Results are cached by the client for the TTL set with SetRoutingCacheTTL (10 minutes by default).
+// The returned *http.Response is nil when the route is served from the cache.
+func (s *RoutingService) Lookup(ctx context.Context, attributes AccountAttributes) (*Route, *http.Response, error) {
+	key := routingCacheKey{attributes.BankID, attributes.BankIDCode, attributes.Bic}
+	if route, ok := s.client.routingCache.get(key); ok {
+		return route, nil, nil
+	}
+
+	params := url.Values{}
+	if attributes.BankID != "" {
+		params.Add("filter[bank_id]", attributes.BankID)
+	}
+	if attributes.BankIDCode != "" {
+		params.Add("filter[bank_id_code]", attributes.BankIDCode)
+	}
+	if attributes.Bic != "" {
+		params.Add("filter[bic]", attributes.Bic)
+	}
+
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "GET",
+		Path:   routingPath,
+		Params: params,
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret fetchRouteAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	s.client.routingCache.put(key, &ret.Data)
+	return &ret.Data, res, nil
+}
+
+// Reachable -> Report whether the account can be paid through scheme, e.g. Reachable(ctx, account.Attributes, SchemeFPS).
+func (s *RoutingService) Reachable(ctx context.Context, attributes AccountAttributes, scheme string) (bool, error) {
+	route, _, err := s.Lookup(ctx, attributes)
+	if err != nil {
+		return false, err
+	}
+	return route.Reachable(scheme), nil
+}
+
+type routingCacheKey struct {
+	bankID     string
+	bankIDCode string
+	bic        string
+}
+
+type routingCacheEntry struct {
+	route   *Route
+	expires time.Time
+}
+
+// routingCache is a TTL cache of routes shared by every RoutingService of a client.
+// It holds at most maxRoutingCacheEntries routes. Callers get their own copy of a route, so changing it leaves the cache intact.
+type routingCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[routingCacheKey]routingCacheEntry
+}
+
+func newRoutingCache(ttl time.Duration) *routingCache {
+	return &routingCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[routingCacheKey]routingCacheEntry{},
+	}
+}
+
+func (c *routingCache) get(key routingCacheKey) (*Route, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	return entry.route.clone(), true
+}
+
+func (c *routingCache) put(key routingCacheKey, route *Route) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= maxRoutingCacheEntries {
+		c.evict(now)
+	}
+	c.entries[key] = routingCacheEntry{route: route.clone(), expires: now.Add(c.ttl)}
+}
+
+// evict removes the expired entries, or the one expiring first if none has, to make room for a new entry.
+func (c *routingCache) evict(now time.Time) {
+	var first routingCacheKey
+	var firstExpires time.Time
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+			continue
+		}
+		if firstExpires.IsZero() || entry.expires.Before(firstExpires) {
+			first, firstExpires = key, entry.expires
+		}
+	}
+	if len(c.entries) >= maxRoutingCacheEntries {
+		delete(c.entries, first)
+	}
+}
+
+// clone returns a deep copy of the route.
+func (r *Route) clone() *Route {
+	route := *r
+	route.Attributes.Schemes = append([]SchemeRoute(nil), r.Attributes.Schemes...)
+	return &route
+}
@@ -0,0 +1,118 @@
+package form3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_RoutingLookup_Cached(t *testing.T) {
+	lookups := 0
+	srv := serverMock("/v1/routing/lookups", func(w http.ResponseWriter, r *http.Request) {
+		lookups++
+		if r.URL.Query().Get("filter[bank_id]") != "400300" {
+			t.Error("Expected: bank_id filter", "Got:", r.URL.RawQuery)
+		}
+		w.Write([]byte(routeJSON))
+	})
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	now := time.Date(2020, 6, 30, 12, 0, 0, 0, time.UTC)
+	client.routingCache.now = func() time.Time { return now }
+
+	attributes := AccountAttributes{BankID: "400300", BankIDCode: "GBDSC"}
+
+	route, res, err := client.Routing().Lookup(context.Background(), attributes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == nil || http.StatusOK != res.StatusCode {
+		t.Error("Expected:", http.StatusOK, "Got:", res)
+	}
+
+	if scheme, _ := route.Choose(SchemeFPS, SchemeBacs, SchemeCHAPS); scheme != SchemeBacs {
+		t.Error("Expected:", SchemeBacs, "Got:", scheme)
+	}
+
+	// Served from the cache on a new service instance.
+	reachable, err := client.Routing().Reachable(context.Background(), attributes, SchemeFPS)
+	if err != nil {
+		t.Error(err)
+	}
+	if reachable {
+		t.Error("Expected: FPS to be unreachable")
+	}
+	if lookups != 1 {
+		t.Error("Expected: 1 lookup", "Got:", lookups)
+	}
+
+	// Looked up again once the entry expires.
+	now = now.Add(defaultRoutingCacheTTL)
+	if _, _, err := client.Routing().Lookup(context.Background(), attributes); err != nil {
+		t.Error(err)
+	}
+	if lookups != 2 {
+		t.Error("Expected: 2 lookups", "Got:", lookups)
+	}
+}
+
+func Test_RoutingCache_Isolated(t *testing.T) {
+	cache := newRoutingCache(time.Minute)
+	key := routingCacheKey{bankID: "400300"}
+
+	route := &Route{Attributes: RouteAttributes{Schemes: []SchemeRoute{{Scheme: SchemeFPS, Reachable: true}}}}
+	cache.put(key, route)
+
+	// Changing the stored or the returned route leaves the cache intact
+	route.Attributes.Schemes[0].Reachable = false
+	got, _ := cache.get(key)
+	got.Attributes.Schemes[0].Scheme = SchemeBacs
+
+	if got, _ := cache.get(key); !got.Reachable(SchemeFPS) {
+		t.Error("Expected: FPS to be reachable", "Got:", got.Attributes.Schemes)
+	}
+}
+
+func Test_RoutingCache_Evicts(t *testing.T) {
+	now := time.Date(2020, 6, 30, 12, 0, 0, 0, time.UTC)
+	cache := newRoutingCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	for i := 0; i < maxRoutingCacheEntries; i++ {
+		cache.put(routingCacheKey{bankID: fmt.Sprint(i)}, &Route{})
+	}
+
+	// Full of live entries: the one expiring first makes room
+	now = now.Add(time.Second)
+	cache.put(routingCacheKey{bankID: "new"}, &Route{})
+	if len(cache.entries) != maxRoutingCacheEntries {
+		t.Error("Expected:", maxRoutingCacheEntries, "Got:", len(cache.entries))
+	}
+
+	// Expired entries are swept, even if they are never looked up again
+	now = now.Add(time.Minute)
+	cache.put(routingCacheKey{bankID: "newer"}, &Route{})
+	if len(cache.entries) != 1 {
+		t.Error("Expected: 1", "Got:", len(cache.entries))
+	}
+}
+
+var routeJSON = `{
+	"data": {
+		"type": "routes",
+		"id": "400300",
+		"attributes": {
+			"bank_id": "400300",
+			"bank_id_code": "GBDSC",
+			"country": "GB",
+			"schemes": [
+				{"scheme": "FPS", "reachable": false},
+				{"scheme": "Bacs", "gateway": "bacs-gw", "reachable": true},
+				{"scheme": "CHAPS", "gateway": "chaps-gw", "reachable": true}
+			]
+		}
+	}
+}`
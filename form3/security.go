@@ -0,0 +1,405 @@
+package form3
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	usersPath string = "/security/users"
+	rolesPath string = "/security/roles"
+)
+
+// Actions that an access control entry can grant on a record type.
+const (
+	ActionCreate  string = "CREATE"
+	ActionRead    string = "READ"
+	ActionEdit    string = "EDIT"
+	ActionDelete  string = "DELETE"
+	ActionApprove string = "APPROVE"
+)
+
+// User represents an API user of an organisation.
+type User struct {
+	Attributes     UserAttributes `json:"attributes"`
+	ID             string         `json:"id"`
+	OrganisationID string         `json:"organisation_id"`
+	Type           string         `json:"type"`
+	Version        int            `json:"version"`
+}
+
+// UserAttributes represents attributes of a User
+type UserAttributes struct {
+	Username string   `json:"username"`
+	Email    string   `json:"email,omitempty"`
+	RoleIDs  []string `json:"role_ids,omitempty"`
+}
+
+// Role represents a named set of permissions that can be assigned to users.
+type Role struct {
+	Attributes     RoleAttributes `json:"attributes"`
+	ID             string         `json:"id"`
+	OrganisationID string         `json:"organisation_id"`
+	Type           string         `json:"type"`
+	Version        int            `json:"version"`
+}
+
+// RoleAttributes represents attributes of a Role
+type RoleAttributes struct {
+	Name string `json:"name"`
+}
+
+// ACE represents an access control entry: permission for a role to perform an action on a record type.
+type ACE struct {
+	Attributes     ACEAttributes `json:"attributes"`
+	ID             string        `json:"id"`
+	OrganisationID string        `json:"organisation_id"`
+	Type           string        `json:"type"`
+	Version        int           `json:"version"`
+}
+
+// ACEAttributes represents attributes of an ACE
+type ACEAttributes struct {
+	RoleID     string `json:"role_id"`
+	Action     string `json:"action"`
+	RecordType string `json:"record_type"`
+}
+
+// Credential represents a public key uploaded for a user to sign API requests with.
+type Credential struct {
+	Attributes     CredentialAttributes `json:"attributes"`
+	ID             string               `json:"id"`
+	OrganisationID string               `json:"organisation_id"`
+	Type           string               `json:"type"`
+	Version        int                  `json:"version"`
+}
+
+// CredentialAttributes represents attributes of a Credential
+type CredentialAttributes struct {
+	PublicKey string `json:"public_key"`
+	// PublicKeyID is assigned by Form3 and is used as the keyId when signing requests.
+	PublicKeyID string `json:"public_key_id,omitempty"`
+}
+
+// SecurityService implements a service to manage users, roles, access control entries and credentials
+// See https://api-docs.form3.tech/api.html#security
+type SecurityService struct {
+	client     *Client
+	pagination Pagination
+}
+
+// NewSecurityService creates a new SecurityService.
+func NewSecurityService(client *Client) *SecurityService {
+	return &SecurityService{
+		client:     client,
+		pagination: NewPagination(),
+	}
+}
+
+type fetchUserAPIResponse struct {
+	Data  User  `json:"data"`
+	Links Links `json:"links"`
+}
+
+type userAPIPayload struct {
+	Data User `json:"data"`
+}
+
+type listUsersAPIResponse struct {
+	Data  []User `json:"data"`
+	Links Links  `json:"links"`
+}
+
+type fetchRoleAPIResponse struct {
+	Data  Role  `json:"data"`
+	Links Links `json:"links"`
+}
+
+type roleAPIPayload struct {
+	Data Role `json:"data"`
+}
+
+type listRolesAPIResponse struct {
+	Data  []Role `json:"data"`
+	Links Links  `json:"links"`
+}
+
+type fetchACEAPIResponse struct {
+	Data  ACE   `json:"data"`
+	Links Links `json:"links"`
+}
+
+type aceAPIPayload struct {
+	Data ACE `json:"data"`
+}
+
+type listACEsAPIResponse struct {
+	Data  []ACE `json:"data"`
+	Links Links `json:"links"`
+}
+
+type fetchCredentialAPIResponse struct {
+	Data  Credential `json:"data"`
+	Links Links      `json:"links"`
+}
+
+type credentialAPIPayload struct {
+	Data Credential `json:"data"`
+}
+
+type listCredentialsAPIResponse struct {
+	Data  []Credential `json:"data"`
+	Links Links        `json:"links"`
+}
+
+// FetchUser -> Get a single user using the user ID.
+//
+// GET /v1/security/users/{user_id}
+func (s *SecurityService) FetchUser(ctx context.Context, id string) (*User, *http.Response, error) {
+	var ret fetchUserAPIResponse
+	res, err := s.do(ctx, "GET", fmt.Sprintf("%s/%s", usersPath, id), nil, nil, &ret)
+	if err != nil {
+		return nil, res, err
+	}
+	return &ret.Data, res, nil
+}
+
+// ListUsers -> List users, paged using Number and Size.
+//
+// GET /v1/security/users?page[number]={page_number}&page[size]={page_size}
+func (s *SecurityService) ListUsers(ctx context.Context) ([]User, *http.Response, error) {
+	var ret listUsersAPIResponse
+	res, err := s.do(ctx, "GET", usersPath, s.pagination.Params(), nil, &ret)
+	if err != nil {
+		return nil, res, err
+	}
+	return ret.Data, res, nil
+}
+
+// CreateUser -> Create a new user.
+//
+// POST /v1/security/users
+func (s *SecurityService) CreateUser(ctx context.Context, user *User) (*User, *http.Response, error) {
+	data := &userAPIPayload{Data: *user}
+	if data.Data.OrganisationID == "" {
+		data.Data.OrganisationID = s.client.organisationID
+	}
+
+	var ret fetchUserAPIResponse
+	res, err := s.do(ctx, "POST", usersPath, nil, data, &ret)
+	if err != nil {
+		return nil, res, err
+	}
+	return &ret.Data, res, nil
+}
+
+// DeleteUser -> Delete a user
+//
+// DELETE /v1/security/users/{user_id}?version={version}
+func (s *SecurityService) DeleteUser(ctx context.Context, id string, version int) (bool, *http.Response, error) {
+	return s.delete(ctx, fmt.Sprintf("%s/%s", usersPath, id), version)
+}
+
+// FetchRole -> Get a single role using the role ID.
+//
+// GET /v1/security/roles/{role_id}
+func (s *SecurityService) FetchRole(ctx context.Context, id string) (*Role, *http.Response, error) {
+	var ret fetchRoleAPIResponse
+	res, err := s.do(ctx, "GET", fmt.Sprintf("%s/%s", rolesPath, id), nil, nil, &ret)
+	if err != nil {
+		return nil, res, err
+	}
+	return &ret.Data, res, nil
+}
+
+// ListRoles -> List roles, paged using Number and Size.
+//
+// GET /v1/security/roles?page[number]={page_number}&page[size]={page_size}
+func (s *SecurityService) ListRoles(ctx context.Context) ([]Role, *http.Response, error) {
+	ret, res, err := s.listRoles(ctx, s.pagination.Params())
+	if err != nil {
+		return nil, res, err
+	}
+	return ret.Data, res, nil
+}
+
+// CreateRole -> Create a new role.
+//
+// POST /v1/security/roles
+func (s *SecurityService) CreateRole(ctx context.Context, role *Role) (*Role, *http.Response, error) {
+	data := &roleAPIPayload{Data: *role}
+	if data.Data.OrganisationID == "" {
+		data.Data.OrganisationID = s.client.organisationID
+	}
+
+	var ret fetchRoleAPIResponse
+	res, err := s.do(ctx, "POST", rolesPath, nil, data, &ret)
+	if err != nil {
+		return nil, res, err
+	}
+	return &ret.Data, res, nil
+}
+
+// DeleteRole -> Delete a role
+//
+// DELETE /v1/security/roles/{role_id}?version={version}
+func (s *SecurityService) DeleteRole(ctx context.Context, id string, version int) (bool, *http.Response, error) {
+	return s.delete(ctx, fmt.Sprintf("%s/%s", rolesPath, id), version)
+}
+
+// ListACEs -> List the access control entries of a role, paged using Number and Size.
+//
+// GET /v1/security/roles/{role_id}/aces?page[number]={page_number}&page[size]={page_size}
+func (s *SecurityService) ListACEs(ctx context.Context, roleID string) ([]ACE, *http.Response, error) {
+	ret, res, err := s.listACEs(ctx, roleID, s.pagination.Params())
+	if err != nil {
+		return nil, res, err
+	}
+	return ret.Data, res, nil
+}
+
+// CreateACE -> Grant a role permission to perform an action on a record type.
+//
+// POST /v1/security/roles/{role_id}/aces
+func (s *SecurityService) CreateACE(ctx context.Context, roleID string, ace *ACE) (*ACE, *http.Response, error) {
+	data := &aceAPIPayload{Data: *ace}
+	data.Data.Attributes.RoleID = roleID
+	if data.Data.OrganisationID == "" {
+		data.Data.OrganisationID = s.client.organisationID
+	}
+
+	var ret fetchACEAPIResponse
+	res, err := s.do(ctx, "POST", fmt.Sprintf("%s/%s/aces", rolesPath, roleID), nil, data, &ret)
+	if err != nil {
+		return nil, res, err
+	}
+	return &ret.Data, res, nil
+}
+
+// DeleteACE -> Revoke an access control entry from a role
+//
+// DELETE /v1/security/roles/{role_id}/aces/{ace_id}?version={version}
+func (s *SecurityService) DeleteACE(ctx context.Context, roleID, id string, version int) (bool, *http.Response, error) {
+	return s.delete(ctx, fmt.Sprintf("%s/%s/aces/%s", rolesPath, roleID, id), version)
+}
+
+// ListCredentials -> List the public keys uploaded for a user.
+//
+// GET /v1/security/users/{user_id}/credentials/public_key
+func (s *SecurityService) ListCredentials(ctx context.Context, userID string) ([]Credential, *http.Response, error) {
+	var ret listCredentialsAPIResponse
+	res, err := s.do(ctx, "GET", fmt.Sprintf("%s/%s/credentials/public_key", usersPath, userID), nil, nil, &ret)
+	if err != nil {
+		return nil, res, err
+	}
+	return ret.Data, res, nil
+}
+
+// UploadPublicKey -> Upload a PEM encoded public key for a user to sign requests with.
+//
+// POST /v1/security/users/{user_id}/credentials/public_key
+func (s *SecurityService) UploadPublicKey(ctx context.Context, userID, publicKeyPEM string) (*Credential, *http.Response, error) {
+	data := &credentialAPIPayload{Data: Credential{
+		ID:             newUUID(),
+		OrganisationID: s.client.organisationID,
+		Type:           "credentials",
+		Attributes:     CredentialAttributes{PublicKey: publicKeyPEM},
+	}}
+
+	var ret fetchCredentialAPIResponse
+	res, err := s.do(ctx, "POST", fmt.Sprintf("%s/%s/credentials/public_key", usersPath, userID), nil, data, &ret)
+	if err != nil {
+		return nil, res, err
+	}
+	return &ret.Data, res, nil
+}
+
+// DeleteCredential -> Delete a public key uploaded for a user
+//
+// DELETE /v1/security/users/{user_id}/credentials/public_key/{credential_id}
+func (s *SecurityService) DeleteCredential(ctx context.Context, userID, id string) (bool, *http.Response, error) {
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("%s/%s/credentials/public_key/%s", usersPath, userID, id),
+	})
+	if err != nil {
+		return false, res, err
+	}
+	return true, res, nil
+}
+
+// Number -> page number requested. Defaults to 0.
+func (s *SecurityService) Number(number int) SecurityAPI {
+	s.pagination.Number = number
+	return s
+}
+
+// Size -> size is the max number of resources to return. Defaults to 10.
+func (s *SecurityService) Size(size int) SecurityAPI {
+	s.pagination.Size = size
+	return s
+}
+
+func (s *SecurityService) listRoles(ctx context.Context, params url.Values) (*listRolesAPIResponse, *http.Response, error) {
+	var ret listRolesAPIResponse
+	res, err := s.do(ctx, "GET", rolesPath, params, nil, &ret)
+	if err != nil {
+		return nil, res, err
+	}
+	return &ret, res, nil
+}
+
+func (s *SecurityService) listACEs(ctx context.Context, roleID string, params url.Values) (*listACEsAPIResponse, *http.Response, error) {
+	var ret listACEsAPIResponse
+	res, err := s.do(ctx, "GET", fmt.Sprintf("%s/%s/aces", rolesPath, roleID), params, nil, &ret)
+	if err != nil {
+		return nil, res, err
+	}
+	return &ret, res, nil
+}
+
+// do makes a request and decodes the response body into v.
+func (s *SecurityService) do(ctx context.Context, method, path string, params url.Values, body, v interface{}) (*http.Response, error) {
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: method,
+		Path:   path,
+		Params: params,
+		Body:   body,
+	})
+	if err != nil {
+		return res, err
+	}
+
+	return res, s.client.Decode(res, v)
+}
+
+func (s *SecurityService) delete(ctx context.Context, path string, version int) (bool, *http.Response, error) {
+	params := url.Values{}
+	params.Add("version", strconv.Itoa(version))
+
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "DELETE",
+		Path:   path,
+		Params: params,
+	})
+	if err != nil {
+		return false, res, err
+	}
+
+	return true, res, nil
+}
+
+// newUUID returns a random (version 4) UUID for new resources.
+func newUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,217 @@
+package form3
+
+import (
+	"context"
+)
+
+// RoleSpec declares a role and the complete set of access control entries it should have.
+type RoleSpec struct {
+	Name string
+	ACEs []ACESpec
+}
+
+// ACESpec declares permission to perform Action on RecordType.
+type ACESpec struct {
+	Action     string
+	RecordType string
+}
+
+// SecurityPlan describes the changes needed to converge an organisation's roles onto a declared set.
+// Create one by calling SecurityService.Diff and execute it with SecurityService.Apply.
+type SecurityPlan struct {
+	OrganisationID string
+	// CreateRoles are declared roles that do not exist yet. Their ACEs are created along with them.
+	CreateRoles []RoleSpec
+	// CreateACEs are declared ACEs missing from existing roles.
+	CreateACEs []ACE
+	// DeleteACEs are ACEs on declared roles that are not declared.
+	DeleteACEs []ACE
+	// DeleteRoles are roles that are not declared. Only populated when pruning.
+	DeleteRoles []Role
+}
+
+// Empty reports whether the plan makes no changes.
+func (p *SecurityPlan) Empty() bool {
+	return len(p.CreateRoles) == 0 && len(p.CreateACEs) == 0 && len(p.DeleteACEs) == 0 && len(p.DeleteRoles) == 0
+}
+
+// Diff -> Compare the organisation's roles and ACEs with the declared set and plan the changes needed to converge them.
+// Roles are matched by name. Roles that are not declared are left alone unless prune is true.
+func (s *SecurityService) Diff(ctx context.Context, organisationID string, desired []RoleSpec, prune bool) (*SecurityPlan, error) {
+	existing, err := s.allRoles(ctx, organisationID)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]Role, len(existing))
+	for _, role := range existing {
+		byName[role.Attributes.Name] = role
+	}
+
+	plan := &SecurityPlan{OrganisationID: organisationID}
+	declared := map[string]bool{}
+
+	for _, spec := range desired {
+		declared[spec.Name] = true
+
+		role, ok := byName[spec.Name]
+		if !ok {
+			plan.CreateRoles = append(plan.CreateRoles, spec)
+			continue
+		}
+
+		aces, err := s.allACEs(ctx, role.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		want := map[ACESpec]bool{}
+		for _, ace := range spec.ACEs {
+			want[ace] = true
+		}
+
+		have := map[ACESpec]bool{}
+		for _, ace := range aces {
+			key := ACESpec{Action: ace.Attributes.Action, RecordType: ace.Attributes.RecordType}
+			if !want[key] || have[key] {
+				plan.DeleteACEs = append(plan.DeleteACEs, ace)
+				continue
+			}
+			have[key] = true
+		}
+
+		for _, ace := range spec.ACEs {
+			if have[ace] {
+				continue
+			}
+			have[ace] = true
+			plan.CreateACEs = append(plan.CreateACEs, newACE(organisationID, role.ID, ace))
+		}
+	}
+
+	if prune {
+		for _, role := range existing {
+			if !declared[role.Attributes.Name] {
+				plan.DeleteRoles = append(plan.DeleteRoles, role)
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// Apply -> Execute a plan created by Diff.
+// Changes are applied in order (role creations, ACE creations, ACE deletions, role deletions) and stop at the first error.
+func (s *SecurityService) Apply(ctx context.Context, plan *SecurityPlan) error {
+	for _, spec := range plan.CreateRoles {
+		role, _, err := s.CreateRole(ctx, &Role{
+			ID:             newUUID(),
+			OrganisationID: plan.OrganisationID,
+			Type:           "roles",
+			Attributes:     RoleAttributes{Name: spec.Name},
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, aceSpec := range spec.ACEs {
+			ace := newACE(plan.OrganisationID, role.ID, aceSpec)
+			if _, _, err := s.CreateACE(ctx, role.ID, &ace); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i := range plan.CreateACEs {
+		ace := &plan.CreateACEs[i]
+		if _, _, err := s.CreateACE(ctx, ace.Attributes.RoleID, ace); err != nil {
+			return err
+		}
+	}
+
+	for _, ace := range plan.DeleteACEs {
+		if _, _, err := s.DeleteACE(ctx, ace.Attributes.RoleID, ace.ID, ace.Version); err != nil {
+			return err
+		}
+	}
+
+	for _, role := range plan.DeleteRoles {
+		if _, _, err := s.DeleteRole(ctx, role.ID, role.Version); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Converge -> Diff and Apply in one step, returning the plan that was applied.
+func (s *SecurityService) Converge(ctx context.Context, organisationID string, desired []RoleSpec, prune bool) (*SecurityPlan, error) {
+	plan, err := s.Diff(ctx, organisationID, desired, prune)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, s.Apply(ctx, plan)
+}
+
+func (s *SecurityService) allRoles(ctx context.Context, organisationID string) ([]Role, error) {
+	var roles []Role
+
+	page := Pagination{Number: 0, Size: s.pagination.Size}
+	for {
+		params := page.Params()
+		params.Add("filter[organisation_id]", organisationID)
+
+		ret, _, err := s.listRoles(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, role := range ret.Data {
+			if role.OrganisationID == organisationID {
+				roles = append(roles, role)
+			}
+		}
+
+		if lastPage(len(ret.Data), ret.Links) {
+			return roles, nil
+		}
+		page.Number++
+	}
+}
+
+func (s *SecurityService) allACEs(ctx context.Context, roleID string) ([]ACE, error) {
+	var aces []ACE
+
+	page := Pagination{Number: 0, Size: s.pagination.Size}
+	for {
+		ret, _, err := s.listACEs(ctx, roleID, page.Params())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ace := range ret.Data {
+			// The role ID is implied by the path; make sure it is set for DeleteACE.
+			ace.Attributes.RoleID = roleID
+			aces = append(aces, ace)
+		}
+
+		if lastPage(len(ret.Data), ret.Links) {
+			return aces, nil
+		}
+		page.Number++
+	}
+}
+
+func newACE(organisationID, roleID string, spec ACESpec) ACE {
+	return ACE{
+		ID:             newUUID(),
+		OrganisationID: organisationID,
+		Type:           "aces",
+		Attributes: ACEAttributes{
+			RoleID:     roleID,
+			Action:     spec.Action,
+			RecordType: spec.RecordType,
+		},
+	}
+}
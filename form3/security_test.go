@@ -0,0 +1,150 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func Test_FetchRole_Success(t *testing.T) {
+	client, srv := testClient("/v1/security/roles/a1b2c3d4-0000-4000-8000-000000000001", http.StatusOK, roleJSON)
+	defer srv.Close()
+
+	role, res, err := client.Security().FetchRole(context.Background(), "a1b2c3d4-0000-4000-8000-000000000001")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if http.StatusOK != res.StatusCode {
+		t.Error("Expected:", http.StatusOK, "Got:", res.StatusCode)
+	}
+
+	if role.Attributes.Name != "payments-reader" {
+		t.Error("Expected: payments-reader", "Got:", role.Attributes.Name)
+	}
+}
+
+func Test_SecurityDiff(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/security/roles", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page[number]") != "0" {
+			fmt.Fprint(w, `{"data": []}`)
+			return
+		}
+		fmt.Fprint(w, `{"data": [
+			{"id": "r1", "organisation_id": "org", "attributes": {"name": "accounts-admin"}},
+			{"id": "r2", "organisation_id": "org", "attributes": {"name": "legacy"}}
+		]}`)
+	})
+	mux.HandleFunc("/v1/security/roles/r1/aces", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page[number]") != "0" {
+			fmt.Fprint(w, `{"data": []}`)
+			return
+		}
+		fmt.Fprint(w, `{"data": [
+			{"id": "a1", "version": 0, "attributes": {"action": "READ", "record_type": "accounts"}},
+			{"id": "a2", "version": 1, "attributes": {"action": "DELETE", "record_type": "accounts"}}
+		]}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	desired := []RoleSpec{
+		{Name: "accounts-admin", ACEs: []ACESpec{
+			{Action: ActionRead, RecordType: "accounts"},
+			{Action: ActionCreate, RecordType: "accounts"},
+		}},
+		{Name: "payments-reader", ACEs: []ACESpec{
+			{Action: ActionRead, RecordType: "payments"},
+		}},
+	}
+
+	plan, err := client.Security().Diff(context.Background(), "org", desired, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(plan.CreateRoles) != 1 || plan.CreateRoles[0].Name != "payments-reader" {
+		t.Error("Expected: payments-reader to be created", "Got:", plan.CreateRoles)
+	}
+
+	if len(plan.CreateACEs) != 1 || plan.CreateACEs[0].Attributes.Action != ActionCreate || plan.CreateACEs[0].Attributes.RoleID != "r1" {
+		t.Error("Expected: CREATE accounts on r1 to be created", "Got:", plan.CreateACEs)
+	}
+
+	if len(plan.DeleteACEs) != 1 || plan.DeleteACEs[0].ID != "a2" || plan.DeleteACEs[0].Attributes.RoleID != "r1" {
+		t.Error("Expected: a2 to be deleted", "Got:", plan.DeleteACEs)
+	}
+
+	if len(plan.DeleteRoles) != 1 || plan.DeleteRoles[0].ID != "r2" {
+		t.Error("Expected: r2 to be deleted", "Got:", plan.DeleteRoles)
+	}
+}
+
+func Test_SecurityDiff_SizeAboveCap_Success(t *testing.T) {
+	actions := []string{ActionRead, ActionCreate, ActionDelete}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/security/roles", func(w http.ResponseWriter, r *http.Request) {
+		start, end, links := cappedPage(r, 5, 2)
+
+		var data []Role
+		for i := start; i < end; i++ {
+			data = append(data, Role{ID: fmt.Sprintf("r%d", i), OrganisationID: "org", Attributes: RoleAttributes{Name: fmt.Sprintf("role-%d", i)}})
+		}
+		json.NewEncoder(w).Encode(listRolesAPIResponse{Data: data, Links: links})
+	})
+	mux.HandleFunc("/v1/security/roles/r4/aces", func(w http.ResponseWriter, r *http.Request) {
+		start, end, links := cappedPage(r, len(actions), 2)
+
+		var data []ACE
+		for i := start; i < end; i++ {
+			data = append(data, ACE{ID: fmt.Sprintf("a%d", i), Attributes: ACEAttributes{Action: actions[i], RecordType: "accounts"}})
+		}
+		json.NewEncoder(w).Encode(listACEsAPIResponse{Data: data, Links: links})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	// role-4 and its last ACE are past pages shorter than the size asked for
+	desired := []RoleSpec{{Name: "role-4", ACEs: []ACESpec{
+		{Action: ActionRead, RecordType: "accounts"},
+		{Action: ActionCreate, RecordType: "accounts"},
+		{Action: ActionDelete, RecordType: "accounts"},
+	}}}
+	plan, err := client.Security().Size(3).Diff(context.Background(), "org", desired, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !plan.Empty() {
+		t.Error("Expected: no changes", "Got:", plan)
+	}
+}
+
+func Test_NewUUID(t *testing.T) {
+	v4 := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	id := newUUID()
+	if !v4.MatchString(id) {
+		t.Error("Expected a version 4 UUID", "Got:", id)
+	}
+}
+
+var roleJSON = `{
+	"data": {
+		"type": "roles",
+		"id": "a1b2c3d4-0000-4000-8000-000000000001",
+		"version": 0,
+		"organisation_id": "158f775d-4ecd-4861-b33d-30df9a29de78",
+		"attributes": {
+			"name": "payments-reader"
+		}
+	}
+}`
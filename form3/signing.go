@@ -0,0 +1,131 @@
+package form3
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// requestSigner signs requests with a private key, as described in
+// https://datatracker.ietf.org/doc/html/draft-cavage-http-signatures.
+type requestSigner struct {
+	keyID     string
+	key       crypto.Signer
+	algorithm string
+	now       func() time.Time
+}
+
+// SetSigningKey signs every request with key, a *rsa.PrivateKey, *ecdsa.PrivateKey or ed25519.PrivateKey whose
+// public key was uploaded with SecurityService.UploadPublicKey. keyID is the PublicKeyID of that credential.
+// The signature replaces any SetAuthToken bearer token. See LoadSigningKey to read a key from a PEM file.
+func SetSigningKey(keyID string, key crypto.Signer) ClientOptionFunc {
+	return func(c *Client) error {
+		if keyID == "" {
+			return errors.New("signing key ID is empty")
+		}
+
+		signer := &requestSigner{keyID: keyID, key: key, now: time.Now}
+		switch key.(type) {
+		case *rsa.PrivateKey:
+			signer.algorithm = "rsa-sha256"
+		case *ecdsa.PrivateKey:
+			signer.algorithm = "ecdsa-sha256"
+		case ed25519.PrivateKey:
+			signer.algorithm = "ed25519"
+		default:
+			return fmt.Errorf("unsupported signing key type %T", key)
+		}
+
+		c.signer = signer
+		return nil
+	}
+}
+
+// LoadSigningKey reads a PEM encoded PKCS #8, PKCS #1 (RSA) or SEC 1 (EC) private key from path.
+func LoadSigningKey(path string) (crypto.Signer, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("signing key: %w", err)
+	}
+
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("signing key %s: no PEM data found", path)
+	}
+
+	var key interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("signing key %s: unsupported PEM block %q, want a private key", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("signing key %s: %w", path, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("signing key %s: unsupported key type %T", path, key)
+	}
+	return signer, nil
+}
+
+// sign adds the Date, Digest and Authorization headers to request, whose body is payload.
+func (s *requestSigner) sign(request *http.Request, payload []byte) error {
+	request.Header.Set("Date", s.now().UTC().Format(http.TimeFormat))
+
+	headers := []string{"(request-target)", "host", "date"}
+	values := map[string]string{
+		"(request-target)": strings.ToLower(request.Method) + " " + request.URL.RequestURI(),
+		"host":             request.URL.Host,
+		"date":             request.Header.Get("Date"),
+	}
+	if len(payload) > 0 {
+		digest := sha256.Sum256(payload)
+		request.Header.Set("Digest", "SHA-256="+base64.StdEncoding.EncodeToString(digest[:]))
+		headers = append(headers, "content-type", "content-length", "digest")
+		values["content-type"] = request.Header.Get("Content-Type")
+		values["content-length"] = strconv.Itoa(len(payload))
+		values["digest"] = request.Header.Get("Digest")
+	}
+
+	lines := make([]string, len(headers))
+	for i, header := range headers {
+		lines[i] = header + ": " + values[header]
+	}
+
+	signature, err := s.signString(strings.Join(lines, "\n"))
+	if err != nil {
+		return fmt.Errorf("signing request: %w", err)
+	}
+
+	request.Header.Set("Authorization", fmt.Sprintf(`Signature keyId="%s",algorithm="%s",headers="%s",signature="%s"`,
+		s.keyID, s.algorithm, strings.Join(headers, " "), base64.StdEncoding.EncodeToString(signature)))
+	return nil
+}
+
+func (s *requestSigner) signString(signingString string) ([]byte, error) {
+	if _, ok := s.key.(ed25519.PrivateKey); ok {
+		return s.key.Sign(rand.Reader, []byte(signingString), crypto.Hash(0))
+	}
+	digest := sha256.Sum256([]byte(signingString))
+	return s.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
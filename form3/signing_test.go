@@ -0,0 +1,130 @@
+package form3
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var signaturePattern = regexp.MustCompile(`^Signature keyId="(.*)",algorithm="(.*)",headers="(.*)",signature="(.*)"$`)
+
+func Test_SigningKey_Success(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	var verifyErr error
+	srv := serverMock("/v1/organisation/accounts", func(w http.ResponseWriter, r *http.Request) {
+		verifyErr = verifySignature(r, "75a8ba12-fff2-4a52-ad8a-e8b34c5ccec8", &key.PublicKey)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(accountJSON))
+	})
+	defer srv.Close()
+
+	client := testClientFor(srv)
+	if err := SetSigningKey("75a8ba12-fff2-4a52-ad8a-e8b34c5ccec8", key)(client); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := client.Accounts().Create(context.Background(), &Account{ID: "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"}); err != nil {
+		t.Fatal(err)
+	}
+	if verifyErr != nil {
+		t.Error("Expected:", nil, "Got:", verifyErr)
+	}
+}
+
+func Test_LoadSigningKey_Success(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	path := writeKey(t, key)
+
+	loaded, err := LoadSigningKey(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !key.Equal(loaded) {
+		t.Error("Expected:", key, "Got:", loaded)
+	}
+
+	client, _ := NewClient()
+	if err := SetSigningKey("75a8ba12-fff2-4a52-ad8a-e8b34c5ccec8", loaded)(client); err != nil {
+		t.Fatal(err)
+	}
+	if client.signer.algorithm != "ecdsa-sha256" {
+		t.Error("Expected:", "ecdsa-sha256", "Got:", client.signer.algorithm)
+	}
+}
+
+func Test_LoadSigningKey_Failure(t *testing.T) {
+	dir := t.TempDir()
+	notPEM := filepath.Join(dir, "key.pem")
+	os.WriteFile(notPEM, []byte("not a key"), 0600)
+
+	for _, path := range []string{filepath.Join(dir, "missing.pem"), notPEM} {
+		if _, err := LoadSigningKey(path); err == nil {
+			t.Error("Expected: error", "Got:", nil, path)
+		}
+	}
+}
+
+// verifySignature checks the Authorization header of r as the API would.
+func verifySignature(r *http.Request, keyID string, public *rsa.PublicKey) error {
+	params := signaturePattern.FindStringSubmatch(r.Header.Get("Authorization"))
+	if params == nil {
+		return errors.New("no signature in " + r.Header.Get("Authorization"))
+	}
+	if params[1] != keyID || params[2] != "rsa-sha256" {
+		return errors.New("unexpected keyId or algorithm " + params[1] + " " + params[2])
+	}
+
+	body, _ := ioutil.ReadAll(r.Body)
+	digest := sha256.Sum256(body)
+	if r.Header.Get("Digest") != "SHA-256="+base64.StdEncoding.EncodeToString(digest[:]) {
+		return errors.New("digest does not match the body")
+	}
+
+	values := map[string]string{
+		"(request-target)": strings.ToLower(r.Method) + " " + r.URL.RequestURI(),
+		"host":             r.Host,
+		"date":             r.Header.Get("Date"),
+		"content-type":     r.Header.Get("Content-Type"),
+		"content-length":   strconv.Itoa(len(body)),
+		"digest":           r.Header.Get("Digest"),
+	}
+	var lines []string
+	for _, header := range strings.Split(params[3], " ") {
+		lines = append(lines, header+": "+values[header])
+	}
+
+	signature, _ := base64.StdEncoding.DecodeString(params[4])
+	hashed := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return rsa.VerifyPKCS1v15(public, crypto.SHA256, hashed[:], signature)
+}
+
+// writeKey writes key to a PKCS #8 PEM file and returns its path.
+func writeKey(t *testing.T, key crypto.Signer) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
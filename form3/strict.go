@@ -0,0 +1,160 @@
+package form3
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var (
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	rawMessageType  = reflect.TypeOf(json.RawMessage{})
+	extraType       = reflect.TypeOf(map[string]json.RawMessage{})
+)
+
+// StrictDecodingError is returned by Decode in strict mode (see SetStrictDecoding) when a response does not match the models.
+// Fields are reported by their JSON path, e.g. "data.attributes.status" or "data[2].id".
+type StrictDecodingError struct {
+	Unknown []string // members of the response the models do not know
+	Missing []string // required members (without omitempty) missing from the response
+}
+
+func (e *StrictDecodingError) Error() string {
+	var problems []string
+	if len(e.Unknown) > 0 {
+		problems = append(problems, "unknown fields: "+strings.Join(e.Unknown, ", "))
+	}
+	if len(e.Missing) > 0 {
+		problems = append(problems, "missing required fields: "+strings.Join(e.Missing, ", "))
+	}
+	return "strict decoding: " + strings.Join(problems, "; ")
+}
+
+// decodeStrict checks body against the type of v before decoding it, rejecting unknown members.
+func decodeStrict(body []byte, v interface{}) error {
+	var errs StrictDecodingError
+	checkStrict("", body, reflect.TypeOf(v), &errs)
+	if len(errs.Unknown) > 0 || len(errs.Missing) > 0 {
+		sort.Strings(errs.Unknown)
+		sort.Strings(errs.Missing)
+		return &errs
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// checkStrict walks raw alongside t, recording unknown and missing members under path.
+// Values of types with their own UnmarshalJSON are not walked into, except resources keeping an Extra map.
+func checkStrict(path string, raw json.RawMessage, t reflect.Type, errs *StrictDecodingError) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) || t == rawMessageType {
+		return
+	}
+	if reflect.PtrTo(t).Implements(unmarshalerType) && !hasExtra(t) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var members map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &members); err != nil {
+			return
+		}
+
+		for _, field := range jsonFields(t) {
+			value, ok := members[field.name]
+			delete(members, field.name)
+			if !ok {
+				if !field.omitempty {
+					errs.Missing = append(errs.Missing, joinPath(path, field.name))
+				}
+				continue
+			}
+			checkStrict(joinPath(path, field.name), value, field.typ, errs)
+		}
+
+		for name := range members {
+			errs.Unknown = append(errs.Unknown, joinPath(path, name))
+		}
+
+	case reflect.Slice, reflect.Array:
+		var elems []json.RawMessage
+		if err := json.Unmarshal(raw, &elems); err != nil {
+			return
+		}
+		for i, elem := range elems {
+			checkStrict(fmt.Sprintf("%s[%d]", path, i), elem, t.Elem(), errs)
+		}
+
+	case reflect.Map:
+		var members map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &members); err != nil {
+			return
+		}
+		for name, value := range members {
+			checkStrict(joinPath(path, name), value, t.Elem(), errs)
+		}
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// hasExtra reports whether t is a resource keeping unknown members in an Extra map, see unmarshalExtra.
+func hasExtra(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	field, ok := t.FieldByName("Extra")
+	return ok && field.Type == extraType
+}
+
+type jsonField struct {
+	name      string
+	omitempty bool
+	typ       reflect.Type
+}
+
+// jsonFields returns the fields of struct type t encoded to JSON, with the fields of embedded structs inlined.
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			fields = append(fields, jsonFields(field.Type)...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, jsonField{
+			name:      name,
+			omitempty: strings.Contains(opts, "omitempty"),
+			typ:       field.Type,
+		})
+	}
+	return fields
+}
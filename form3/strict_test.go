@@ -0,0 +1,93 @@
+package form3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func Test_FetchAccount_StrictDecoding_Success(t *testing.T) {
+	client, srv := testClient("/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", http.StatusOK, strictAccountJSON)
+	defer srv.Close()
+	SetStrictDecoding(true)(client)
+
+	account, _, err := client.Accounts().Fetch(context.Background(), "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if account.Attributes.BankID != "400300" {
+		t.Error("Expected: 400300", "Got:", account.Attributes.BankID)
+	}
+}
+
+func Test_FetchAccount_StrictDecoding_Failure(t *testing.T) {
+	client, srv := testClient("/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", http.StatusOK, `{
+		"data": {
+			"type": "accounts",
+			"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+			"version": 0,
+			"created_on": "2021-03-01T10:00:00.000Z",
+			"attributes": {"bank_id": "400300", "status": "confirmed"}
+		}
+	}`)
+	defer srv.Close()
+	SetStrictDecoding(true)(client)
+
+	_, _, err := client.Accounts().Fetch(context.Background(), "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc")
+
+	var strictErr *StrictDecodingError
+	if !errors.As(err, &strictErr) {
+		t.Fatal("Expected: *StrictDecodingError", "Got:", err)
+	}
+
+	unknown := []string{"data.attributes.status", "data.created_on"}
+	if !reflect.DeepEqual(strictErr.Unknown, unknown) {
+		t.Error("Expected:", unknown, "Got:", strictErr.Unknown)
+	}
+
+	missing := []string{"data.attributes.country", "data.organisation_id"}
+	if !reflect.DeepEqual(strictErr.Missing, missing) {
+		t.Error("Expected:", missing, "Got:", strictErr.Missing)
+	}
+}
+
+func Test_ListAccounts_StrictDecoding_Failure(t *testing.T) {
+	client, srv := testClient("/v1/organisation/accounts", http.StatusOK, `{
+		"data": [{"type": "accounts", "id": "a", "organisation_id": "o", "version": 0, "attributes": {"country": "GB"}},
+			{"type": "accounts", "id": "b", "organisation_id": "o", "version": 0, "attributes": {"country": "GB", "status": "closed"}}],
+		"links": {"self": "/v1/organisation/accounts"}
+	}`)
+	defer srv.Close()
+	SetStrictDecoding(true)(client)
+
+	_, _, err := client.Accounts().List(context.Background())
+
+	var strictErr *StrictDecodingError
+	if !errors.As(err, &strictErr) {
+		t.Fatal("Expected: *StrictDecodingError", "Got:", err)
+	}
+
+	if !reflect.DeepEqual(strictErr.Unknown, []string{"data[1].attributes.status"}) {
+		t.Error("Expected: [data[1].attributes.status]", "Got:", strictErr.Unknown)
+	}
+}
+
+var strictAccountJSON = `{
+	"data": {
+		"type": "accounts",
+		"id": "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+		"organisation_id": "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+		"version": 0,
+		"attributes": {
+			"country": "GB",
+			"bank_id": "400300",
+			"bank_id_code": "GBDSC"
+		}
+	},
+	"links": {
+		"self": "/v1/organisation/accounts/ad27e265-9605-4b4b-a0e5-3003ea9cc4dc"
+	}
+}`
@@ -0,0 +1,245 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	subscriptionsPath string = "/notification/subscriptions"
+)
+
+// CallbackTransport is the mechanism Form3 uses to deliver notifications to a subscription.
+type CallbackTransport string
+
+// Supported callback transports.
+const (
+	CallbackTransportHTTP  CallbackTransport = "http"
+	CallbackTransportQueue CallbackTransport = "queue"
+)
+
+// Subscription represents a subscription to notifications about events on Form3 resources.
+type Subscription struct {
+	Attributes     SubscriptionAttributes     `json:"attributes"`
+	ID             string                     `json:"id"`
+	OrganisationID string                     `json:"organisation_id"`
+	Type           string                     `json:"type"`
+	Version        int                        `json:"version"`
+	Extra          map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the Subscription, keeping members not known to this client in Extra.
+func (s *Subscription) UnmarshalJSON(b []byte) error {
+	type subscription Subscription
+	extra, err := unmarshalExtra(b, (*subscription)(s))
+	if err != nil {
+		return err
+	}
+	s.Extra = extra
+	return nil
+}
+
+// MarshalJSON encodes the Subscription, including the members in Extra.
+func (s Subscription) MarshalJSON() ([]byte, error) {
+	type subscription Subscription
+	return marshalExtra(subscription(s), s.Extra)
+}
+
+// SubscriptionAttributes represents attributes of a Subscription
+type SubscriptionAttributes struct {
+	CallbackURI       string                     `json:"callback_uri"`
+	CallbackTransport CallbackTransport          `json:"callback_transport"`
+	RecordType        string                     `json:"record_type"`
+	EventType         string                     `json:"event_type"`
+	UserID            string                     `json:"user_id,omitempty"`
+	Deactivated       bool                       `json:"deactivated"`
+	Extra             map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the SubscriptionAttributes, keeping members not known to this client in Extra.
+func (s *SubscriptionAttributes) UnmarshalJSON(b []byte) error {
+	type subscriptionAttributes SubscriptionAttributes
+	extra, err := unmarshalExtra(b, (*subscriptionAttributes)(s))
+	if err != nil {
+		return err
+	}
+	s.Extra = extra
+	return nil
+}
+
+// MarshalJSON encodes the SubscriptionAttributes, including the members in Extra.
+func (s SubscriptionAttributes) MarshalJSON() ([]byte, error) {
+	type subscriptionAttributes SubscriptionAttributes
+	return marshalExtra(subscriptionAttributes(s), s.Extra)
+}
+
+// SubscriptionsService implements a service to manage notification subscriptions
+// See https://api-docs.form3.tech/api.html#subscriptions
+type SubscriptionsService struct {
+	client     *Client
+	pagination Pagination
+}
+
+// NewSubscriptionsService creates a new SubscriptionsService.
+func NewSubscriptionsService(client *Client) *SubscriptionsService {
+	return &SubscriptionsService{
+		client:     client,
+		pagination: NewPagination(),
+	}
+}
+
+type fetchSubscriptionAPIResponse struct {
+	Data  Subscription `json:"data"`
+	Links Links        `json:"links"`
+}
+
+type listSubscriptionsAPIResponse struct {
+	Data  []Subscription `json:"data"`
+	Links Links          `json:"links"`
+}
+
+type subscriptionAPIPayload struct {
+	Data Subscription `json:"data"`
+}
+
+// Fetch -> Get a single subscription using the subscription ID.
+//
+// GET /v1/notification/subscriptions/{subscription_id}
+func (s *SubscriptionsService) Fetch(ctx context.Context, id string) (*Subscription, *http.Response, error) {
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%s", subscriptionsPath, id),
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret fetchSubscriptionAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	return &ret.Data, res, nil
+}
+
+// List -> List subscriptions, paged using Number and Size.
+//
+// GET /v1/notification/subscriptions?page[number]={page_number}&page[size]={page_size}
+func (s *SubscriptionsService) List(ctx context.Context) ([]Subscription, *http.Response, error) {
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "GET",
+		Path:   subscriptionsPath,
+		Params: s.pagination.Params(),
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret listSubscriptionsAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+	return ret.Data, res, nil
+}
+
+// Create -> Create a new subscription.
+//
+// POST /v1/notification/subscriptions
+//
+// The callback URI is a URL for the http transport and a queue identifier for the queue transport.
+// Notifications are only delivered for events matching both the record type and event type.
+// If OrganisationID is empty, the client's default organisation (see SetOrganisationID) is used.
+func (s *SubscriptionsService) Create(ctx context.Context, subscription *Subscription) (*Subscription, *http.Response, error) {
+	data := &subscriptionAPIPayload{Data: *subscription}
+	if data.Data.OrganisationID == "" {
+		data.Data.OrganisationID = s.client.organisationID
+	}
+
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "POST",
+		Path:   subscriptionsPath,
+		Body:   data,
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret fetchSubscriptionAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	return &ret.Data, res, nil
+}
+
+// Update -> Update an existing subscription.
+//
+// PATCH /v1/notification/subscriptions/{subscription_id}
+//
+// The subscription's ID and current version must be set. A 409 Conflict is returned if the version is out of date.
+func (s *SubscriptionsService) Update(ctx context.Context, subscription *Subscription) (*Subscription, *http.Response, error) {
+	data := &subscriptionAPIPayload{Data: *subscription}
+
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "PATCH",
+		Path:   fmt.Sprintf("%s/%s", subscriptionsPath, subscription.ID),
+		Body:   data,
+	})
+	if err != nil {
+		return nil, res, err
+	}
+
+	var ret fetchSubscriptionAPIResponse
+	if err := s.client.Decode(res, &ret); err != nil {
+		return nil, res, err
+	}
+
+	return &ret.Data, res, nil
+}
+
+// Deactivate -> Stop notifications being delivered to a subscription without deleting it.
+//
+// PATCH /v1/notification/subscriptions/{subscription_id}
+func (s *SubscriptionsService) Deactivate(ctx context.Context, subscription *Subscription) (*Subscription, *http.Response, error) {
+	deactivated := *subscription
+	deactivated.Attributes.Deactivated = true
+
+	return s.Update(ctx, &deactivated)
+}
+
+// Delete -> Delete a subscription
+//
+// DELETE /v1/notification/subscriptions/{subscription_id}?version={version}
+//
+// No response body returned.
+func (s *SubscriptionsService) Delete(ctx context.Context, id string, version int) (bool, *http.Response, error) {
+	params := url.Values{}
+	params.Add("version", strconv.Itoa(version))
+
+	res, err := s.client.MakeRequest(ctx, MakeRequestOptions{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("%s/%s", subscriptionsPath, id),
+		Params: params,
+	})
+	if err != nil {
+		return false, res, err
+	}
+
+	return true, res, nil
+}
+
+// Number -> page number requested. Defaults to 0.
+func (s *SubscriptionsService) Number(number int) SubscriptionsAPI {
+	s.pagination.Number = number
+	return s
+}
+
+// Size -> size is the max number of resources to return. Defaults to 10.
+func (s *SubscriptionsService) Size(size int) SubscriptionsAPI {
+	s.pagination.Size = size
+	return s
+}
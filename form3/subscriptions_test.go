@@ -0,0 +1,151 @@
+package form3
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func Test_FetchSubscription_Success(t *testing.T) {
+	client, srv := testClient("/v1/notification/subscriptions/5e2ccb7f-5f5b-4b3e-9a1b-6d0e4f6c1a10", http.StatusOK, subscriptionJSON)
+	defer srv.Close()
+
+	subscription, res, err := client.Subscriptions().Fetch(context.Background(), "5e2ccb7f-5f5b-4b3e-9a1b-6d0e4f6c1a10")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if http.StatusOK != res.StatusCode {
+		t.Error("Expected:", http.StatusOK, "Got:", res.StatusCode)
+	}
+
+	if subscription.Attributes.CallbackTransport != CallbackTransportHTTP {
+		t.Error("Expected:", CallbackTransportHTTP, "Got:", subscription.Attributes.CallbackTransport)
+	}
+
+	if subscription.Attributes.RecordType != "accounts" {
+		t.Error("Expected: accounts", "Got:", subscription.Attributes.RecordType)
+	}
+}
+
+func Test_ListSubscriptions_Success(t *testing.T) {
+	client, srv := testClient("/v1/notification/subscriptions", http.StatusOK, subscriptionsJSON)
+	defer srv.Close()
+
+	subscriptions, res, err := client.Subscriptions().Number(0).Size(10).List(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if http.StatusOK != res.StatusCode {
+		t.Error("Expected:", http.StatusOK, "Got:", res.StatusCode)
+	}
+
+	if len(subscriptions) != 1 {
+		t.Error("Expected:", 1, "Got:", len(subscriptions))
+	}
+}
+
+func Test_CreateSubscription_DefaultOrganisation_Success(t *testing.T) {
+	var payload subscriptionAPIPayload
+	srv := serverMock("/v1/notification/subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&payload)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(subscriptionJSON))
+	})
+	defer srv.Close()
+
+	client := testClientFor(srv)
+	SetOrganisationID("ee2fb143-6dfe-4787-b183-ca8ddd4164d2")(client)
+
+	subscription := &Subscription{ID: "5e2ccb7f-5f5b-4b3e-9a1b-6d0e4f6c1a10"}
+	if _, _, err := client.Subscriptions().Create(context.Background(), subscription); err != nil {
+		t.Error(err)
+	}
+
+	if payload.Data.OrganisationID != "ee2fb143-6dfe-4787-b183-ca8ddd4164d2" {
+		t.Error("Expected: ee2fb143-6dfe-4787-b183-ca8ddd4164d2", "Got:", payload.Data.OrganisationID)
+	}
+
+	if subscription.OrganisationID != "" {
+		t.Error("Expected the caller's subscription to be left unchanged, Got:", subscription.OrganisationID)
+	}
+}
+
+func Test_DeactivateSubscription_Success(t *testing.T) {
+	var method string
+	srv := serverMock("/v1/notification/subscriptions/5e2ccb7f-5f5b-4b3e-9a1b-6d0e4f6c1a10", func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(subscriptionJSON))
+	})
+	defer srv.Close()
+	client := testClientFor(srv)
+
+	_, res, err := client.Subscriptions().Deactivate(context.Background(), &Subscription{ID: "5e2ccb7f-5f5b-4b3e-9a1b-6d0e4f6c1a10"})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if method != "PATCH" {
+		t.Error("Expected: PATCH", "Got:", method)
+	}
+
+	if http.StatusOK != res.StatusCode {
+		t.Error("Expected:", http.StatusOK, "Got:", res.StatusCode)
+	}
+}
+
+func Test_DeleteSubscription_Conflict_Failure(t *testing.T) {
+	client, srv := testClient("/v1/notification/subscriptions/5e2ccb7f-5f5b-4b3e-9a1b-6d0e4f6c1a10", http.StatusConflict, "")
+	defer srv.Close()
+
+	ok, res, err := client.Subscriptions().Delete(context.Background(), "5e2ccb7f-5f5b-4b3e-9a1b-6d0e4f6c1a10", 3)
+	if err == nil {
+		t.Error("Expected: error", "Got: nil")
+	}
+
+	if ok != false {
+		t.Error("Expected: false Got:", ok)
+	}
+
+	if http.StatusConflict != res.StatusCode {
+		t.Error("Expected:", http.StatusConflict, "Got:", res.StatusCode)
+	}
+}
+
+var subscriptionJSON = `{
+	"data": {
+		"type": "subscriptions",
+		"id": "5e2ccb7f-5f5b-4b3e-9a1b-6d0e4f6c1a10",
+		"version": 0,
+		"organisation_id": "158f775d-4ecd-4861-b33d-30df9a29de78",
+		"attributes": {
+			"callback_uri": "https://example.com/form3/events",
+			"callback_transport": "http",
+			"record_type": "accounts",
+			"event_type": "created",
+			"deactivated": false
+		}
+	}
+}`
+
+var subscriptionsJSON = `{
+	"data": [{
+		"type": "subscriptions",
+		"id": "5e2ccb7f-5f5b-4b3e-9a1b-6d0e4f6c1a10",
+		"version": 0,
+		"organisation_id": "158f775d-4ecd-4861-b33d-30df9a29de78",
+		"attributes": {
+			"callback_uri": "my-queue",
+			"callback_transport": "queue",
+			"record_type": "payments",
+			"event_type": "updated",
+			"deactivated": true
+		}
+	}],
+	"links": {
+		"self": "/v1/notification/subscriptions?page%5Bnumber%5D=0&page%5Bsize%5D=10"
+	}
+}`
@@ -0,0 +1,370 @@
+package form3
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultDialTimeout     time.Duration = 10 * time.Second
+	defaultKeepAlive       time.Duration = 30 * time.Second
+	defaultIdleConnTimeout time.Duration = 90 * time.Second
+)
+
+// NewTransport returns the transport clients use by default, hardened for payment traffic:
+// TLS 1.2 or later with forward secret AEAD cipher suites only, timeouts on every step before the response arrives,
+// a bounded connection pool, HTTP/2, and proxies from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+// The options below (SetCABundle, SetProxy, ...) adjust it.
+func NewTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   defaultDialTimeout,
+			KeepAlive: defaultKeepAlive,
+		}).DialContext,
+		TLSClientConfig: &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			// TLS 1.3 suites are not configurable and all qualify
+			CipherSuites: []uint16{
+				tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+				tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+				tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+				tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+				tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+				tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+			},
+		},
+		ForceAttemptHTTP2:      true,
+		TLSHandshakeTimeout:    10 * time.Second,
+		ResponseHeaderTimeout:  30 * time.Second,
+		ExpectContinueTimeout:  1 * time.Second,
+		MaxIdleConns:           100,
+		MaxIdleConnsPerHost:    10,
+		IdleConnTimeout:        defaultIdleConnTimeout,
+		MaxResponseHeaderBytes: 1 << 20,
+	}
+}
+
+// SetHTTPClient replaces the http.Client used to make requests, transport included.
+// It fails after SetTransport or the options adjusting the transport, e.g. SetCABundle, rather than discard them:
+// apply it first, or pass it to NewClient rather than NewClientFromEnv when the profile has transport settings.
+// SetTimeout and WrapTransport apply whatever their order. The other options adjust copies of the client and its
+// transport, leaving those given as they are.
+func SetHTTPClient(client *http.Client) ClientOptionFunc {
+	return func(c *Client) error {
+		if client == nil {
+			return errors.New("http client is nil")
+		}
+		if c.transportSetBy != "" {
+			return fmt.Errorf("apply SetHTTPClient before %s, whose transport it would discard", c.transportSetBy)
+		}
+		httpClient := *client
+		c.httpClient = &httpClient
+		c.transportShared = true
+		return nil
+	}
+}
+
+// SetTLSConfig replaces the TLS configuration of the transport. Prefer SetCABundle and SetClientCertificate,
+// which keep the hardened defaults of NewTransport.
+func SetTLSConfig(config *tls.Config) ClientOptionFunc {
+	return func(c *Client) error {
+		t, err := c.transport()
+		if err != nil {
+			return err
+		}
+		t.TLSClientConfig = config.Clone()
+		return nil
+	}
+}
+
+// SetCABundle trusts the PEM encoded CA certificates in file instead of the system ones,
+// e.g. to reach Form3 through an egress proxy that terminates TLS.
+func SetCABundle(file string) ClientOptionFunc {
+	return func(c *Client) error {
+		pool, err := loadCABundle(file)
+		if err != nil {
+			return err
+		}
+		return setRootCAs(pool)(c)
+	}
+}
+
+// SetClientCertificate presents the PEM encoded certificate and key in certFile and keyFile for mutual TLS,
+// to Form3 or to an HTTPS proxy set with SetProxy.
+func SetClientCertificate(certFile, keyFile string) ClientOptionFunc {
+	return func(c *Client) error {
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return fmt.Errorf("client certificate: %w", err)
+		}
+		return setClientCertificate(certificate)(c)
+	}
+}
+
+func loadCABundle(file string) (*x509.CertPool, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("CA bundle: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(b) {
+		return nil, fmt.Errorf("CA bundle %s: no PEM certificates found", file)
+	}
+	return pool, nil
+}
+
+func setRootCAs(pool *x509.CertPool) ClientOptionFunc {
+	return func(c *Client) error {
+		config, err := c.tlsConfig()
+		if err != nil {
+			return err
+		}
+		config.RootCAs = pool
+		return nil
+	}
+}
+
+func setClientCertificate(certificate tls.Certificate) ClientOptionFunc {
+	return func(c *Client) error {
+		config, err := c.tlsConfig()
+		if err != nil {
+			return err
+		}
+		config.Certificates = []tls.Certificate{certificate}
+		return nil
+	}
+}
+
+// SetProxy sends requests through the HTTP, HTTPS or SOCKS5 proxy at proxyURL, except for hosts matching noProxy
+// (see NO_PROXY: "*", hosts and their subdomains, IPs and CIDR ranges, with an optional port).
+// A nil proxyURL connects directly. Proxies are taken from the environment by default.
+func SetProxy(proxyURL *url.URL, noProxy ...string) ClientOptionFunc {
+	return func(c *Client) error {
+		t, err := c.transport()
+		if err != nil {
+			return err
+		}
+		if proxyURL == nil {
+			t.Proxy = nil
+			return nil
+		}
+
+		if err := checkProxyURL(proxyURL); err != nil {
+			return fmt.Errorf("proxy %q: %w", proxyURL, err)
+		}
+
+		bypass := parseNoProxy(noProxy)
+		t.Proxy = func(r *http.Request) (*url.URL, error) {
+			if bypass.match(r.URL) {
+				return nil, nil
+			}
+			return proxyURL, nil
+		}
+		return nil
+	}
+}
+
+func checkProxyURL(u *url.URL) error {
+	switch {
+	case u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5":
+		return errors.New("scheme must be http, https or socks5")
+	case u.Host == "":
+		return errors.New("host is missing")
+	}
+	return nil
+}
+
+// SetHTTP2 turns HTTP/2 on or off (on by default). HTTP/2 is only used with https.
+func SetHTTP2(enabled bool) ClientOptionFunc {
+	return func(c *Client) error {
+		t, err := c.transport()
+		if err != nil {
+			return err
+		}
+
+		t.ForceAttemptHTTP2 = enabled
+		if enabled {
+			// nil lets net/http configure HTTP/2 again
+			t.TLSNextProto = nil
+		} else {
+			// A non-nil empty map disables HTTP/2, see the net/http documentation
+			t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+			if t.TLSClientConfig != nil {
+				// nor must it be offered to the server, e.g. by the TLS configuration of an httptest client
+				t.TLSClientConfig.NextProtos = withoutProto(t.TLSClientConfig.NextProtos, "h2")
+			}
+		}
+		return nil
+	}
+}
+
+// withoutProto returns protos without proto.
+func withoutProto(protos []string, proto string) []string {
+	var ret []string
+	for _, p := range protos {
+		if p != proto {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+// ConnectionPool tunes how connections are kept and reused, see SetConnectionPool.
+// Zero values keep the defaults of NewTransport.
+type ConnectionPool struct {
+	MaxIdleConns        int           // idle connections kept open in total
+	MaxIdleConnsPerHost int           // idle connections kept open per host
+	MaxConnsPerHost     int           // connections per host, including active ones (no limit by default)
+	IdleConnTimeout     time.Duration // how long idle connections are kept open
+	KeepAlive           time.Duration // interval of TCP keep-alive probes, negative disables them
+	DisableKeepAlives   bool          // use each connection for a single request
+}
+
+// SetConnectionPool tunes the connection pool and keep-alives of the transport.
+func SetConnectionPool(pool ConnectionPool) ClientOptionFunc {
+	return func(c *Client) error {
+		if pool.MaxIdleConns < 0 || pool.MaxIdleConnsPerHost < 0 || pool.MaxConnsPerHost < 0 || pool.IdleConnTimeout < 0 {
+			return errors.New("connection pool limits must not be negative")
+		}
+
+		t, err := c.transport()
+		if err != nil {
+			return err
+		}
+
+		if pool.MaxIdleConns > 0 {
+			t.MaxIdleConns = pool.MaxIdleConns
+		}
+		if pool.MaxIdleConnsPerHost > 0 {
+			t.MaxIdleConnsPerHost = pool.MaxIdleConnsPerHost
+		}
+		if pool.MaxConnsPerHost > 0 {
+			t.MaxConnsPerHost = pool.MaxConnsPerHost
+		}
+		if pool.IdleConnTimeout > 0 {
+			t.IdleConnTimeout = pool.IdleConnTimeout
+		}
+		if pool.KeepAlive != 0 {
+			t.DialContext = (&net.Dialer{Timeout: defaultDialTimeout, KeepAlive: pool.KeepAlive}).DialContext
+		}
+		t.DisableKeepAlives = pool.DisableKeepAlives
+		return nil
+	}
+}
+
+// transport returns the *http.Transport of the client, for the options above to adjust.
+// They cannot adjust other transports, e.g. after SetTransport.
+func (c *Client) transport() (*http.Transport, error) {
+	c.customiseTransport("the transport options, e.g. SetCABundle or SetProxy")
+
+	switch t := c.httpClient.Transport.(type) {
+	case nil:
+		transport := NewTransport()
+		c.httpClient.Transport = transport
+		c.transportShared = false
+		return transport, nil
+	case *http.Transport:
+		if c.transportShared {
+			// Never adjust a transport the caller may share, e.g. http.DefaultTransport
+			t = t.Clone()
+			c.httpClient.Transport = t
+			c.transportShared = false
+		}
+		return t, nil
+	default:
+		return nil, fmt.Errorf("transport is a %T, not an *http.Transport: apply transport options before SetTransport", t)
+	}
+}
+
+// customiseTransport records the first option customising the transport, see SetHTTPClient.
+func (c *Client) customiseTransport(option string) {
+	if c.transportSetBy == "" {
+		c.transportSetBy = option
+	}
+}
+
+// tlsConfig returns the TLS configuration of the transport, creating it if needed.
+func (c *Client) tlsConfig() (*tls.Config, error) {
+	t, err := c.transport()
+	if err != nil {
+		return nil, err
+	}
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return t.TLSClientConfig, nil
+}
+
+// noProxy holds the hosts that SetProxy connects to directly.
+type noProxy struct {
+	all      bool
+	networks []*net.IPNet
+	hosts    []noProxyHost
+}
+
+type noProxyHost struct {
+	host string // IP or domain, matching its subdomains too
+	port string // empty for any port
+}
+
+func parseNoProxy(entries []string) noProxy {
+	var np noProxy
+	for _, entry := range entries {
+		for _, value := range strings.Split(entry, ",") {
+			value = strings.ToLower(strings.TrimSpace(value))
+			switch {
+			case value == "":
+			case value == "*":
+				np.all = true
+			default:
+				if _, network, err := net.ParseCIDR(value); err == nil {
+					np.networks = append(np.networks, network)
+					continue
+				}
+				host, port := value, ""
+				if h, p, err := net.SplitHostPort(value); err == nil {
+					host, port = h, p
+				}
+				np.hosts = append(np.hosts, noProxyHost{host: strings.TrimPrefix(host, "."), port: port})
+			}
+		}
+	}
+	return np
+}
+
+func (np noProxy) match(u *url.URL) bool {
+	if np.all {
+		return true
+	}
+
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	if port == "" {
+		port = map[string]string{"http": "80", "https": "443"}[u.Scheme]
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		for _, network := range np.networks {
+			if network.Contains(ip) {
+				return true
+			}
+		}
+	}
+	for _, h := range np.hosts {
+		if h.port != "" && h.port != port {
+			continue
+		}
+		if host == h.host || strings.HasSuffix(host, "."+h.host) {
+			return true
+		}
+	}
+	return false
+}
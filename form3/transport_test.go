@@ -0,0 +1,279 @@
+package form3
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewTransport(t *testing.T) {
+	transport := NewTransport()
+	if transport.TLSClientConfig.MinVersion != tls.VersionTLS12 {
+		t.Errorf("expected TLS 1.2 or later; got: %x", transport.TLSClientConfig.MinVersion)
+	}
+	if !transport.ForceAttemptHTTP2 || transport.Proxy == nil || transport.TLSHandshakeTimeout == 0 || transport.ResponseHeaderTimeout == 0 {
+		t.Error("expected HTTP/2, proxies from the environment and timeouts")
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	clientCert, clientKey, clientCAs := writeClientCertificate(t, dir)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	srv.Config.ErrorLog = log.New(ioutil.Discard, "", 0) // expected handshake failures
+	srv.StartTLS()
+	defer srv.Close()
+
+	caBundle := filepath.Join(dir, "ca.pem")
+	os.WriteFile(caBundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0600)
+
+	u, _ := url.Parse(srv.URL)
+	request := MakeRequestOptions{Method: "GET", Path: "/organisation/accounts"}
+
+	client, err := NewClient(SetBaseURL(u), SetCABundle(caBundle), SetClientCertificate(clientCert, clientKey), SetInfoLog(nil), SetErrorLog(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.MakeRequest(context.TODO(), request); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without the client certificate the server refuses the connection
+	client, _ = NewClient(SetBaseURL(u), SetCABundle(caBundle), SetInfoLog(nil), SetErrorLog(nil))
+	if _, err := client.MakeRequest(context.TODO(), request); err == nil {
+		t.Error("expected an error without a client certificate")
+	}
+
+	// Without the CA bundle the server is not trusted
+	client, _ = NewClient(SetBaseURL(u), SetClientCertificate(clientCert, clientKey), SetInfoLog(nil), SetErrorLog(nil))
+	if _, err := client.MakeRequest(context.TODO(), request); err == nil {
+		t.Error("expected an error without the CA bundle")
+	}
+}
+
+func TestSetProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	baseURL, _ := url.Parse("http://api.form3.invalid")
+	client, err := NewClient(SetBaseURL(baseURL), SetProxy(proxyURL, "localhost,.internal"), SetInfoLog(nil), SetErrorLog(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.MakeRequest(context.TODO(), MakeRequestOptions{Method: "GET", Path: "/organisation/accounts"}); err != nil {
+		t.Fatal(err)
+	}
+	if proxied != "http://api.form3.invalid/v1/organisation/accounts" {
+		t.Errorf("expected the request to go through the proxy; got: %q", proxied)
+	}
+
+	if _, err := NewClient(SetProxy(&url.URL{Scheme: "ftp", Host: "proxy"})); err == nil {
+		t.Error("expected an error for an ftp proxy")
+	}
+}
+
+func TestNoProxy(t *testing.T) {
+	np := parseNoProxy([]string{"localhost, .internal", "10.0.0.0/8", "api.form3.tech:8443"})
+
+	for raw, expected := range map[string]bool{
+		"http://localhost:8080/v1":          true,
+		"https://egress.internal/form3":     true,
+		"https://internal/form3":            true,
+		"https://notinternal/form3":         false,
+		"http://10.1.2.3/v1":                true,
+		"http://11.1.2.3/v1":                false,
+		"https://api.form3.tech:8443/v1":    true,
+		"https://api.form3.tech/v1":         false,
+		"https://api.staging-form3.tech/v1": false,
+	} {
+		u, _ := url.Parse(raw)
+		if np.match(u) != expected {
+			t.Errorf("expected no proxy for %s to be %v", raw, expected)
+		}
+	}
+
+	if !parseNoProxy([]string{"*"}).match(&url.URL{Scheme: "https", Host: "api.form3.tech"}) {
+		t.Error("expected * to match every host")
+	}
+}
+
+func TestTransportOptions(t *testing.T) {
+	client, err := NewClient(
+		SetHTTPClient(&http.Client{Transport: http.DefaultTransport}),
+		SetHTTP2(false),
+		SetConnectionPool(ConnectionPool{MaxConnsPerHost: 4, IdleConnTimeout: time.Minute}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transport := client.httpClient.Transport.(*http.Transport)
+	if transport == http.DefaultTransport || !http.DefaultTransport.(*http.Transport).ForceAttemptHTTP2 {
+		t.Error("expected http.DefaultTransport to be left alone")
+	}
+	if transport.ForceAttemptHTTP2 || transport.TLSNextProto == nil {
+		t.Error("expected HTTP/2 to be disabled")
+	}
+	if transport.MaxConnsPerHost != 4 || transport.IdleConnTimeout != time.Minute {
+		t.Errorf("expected the connection pool to be tuned; got: %d %s", transport.MaxConnsPerHost, transport.IdleConnTimeout)
+	}
+
+	// Transport options cannot reach into other transports
+	_, err = NewClient(SetTransport(struct{ http.RoundTripper }{http.DefaultTransport}), SetHTTP2(false))
+	if err == nil || !strings.Contains(err.Error(), "before SetTransport") {
+		t.Errorf("expected an error applying SetHTTP2 after SetTransport; got: %v", err)
+	}
+}
+
+func TestSetHTTP2ReEnabled(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+
+	for enabled, protoMajor := range map[bool]int{false: 1, true: 2} {
+		client, err := NewClient(SetHTTPClient(srv.Client()), SetHTTP2(false), SetHTTP2(enabled))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := client.httpClient.Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.ProtoMajor != protoMajor {
+			t.Errorf("expected HTTP/%d after SetHTTP2(%t); got: %s", protoMajor, enabled, res.Proto)
+		}
+	}
+}
+
+func TestWrapTransportKept(t *testing.T) {
+	var wrapped []string
+	wrap := func(name string) ClientOptionFunc {
+		return WrapTransport(func(next http.RoundTripper) http.RoundTripper {
+			wrapped = append(wrapped, name)
+			return struct{ http.RoundTripper }{next}
+		})
+	}
+
+	// Wrappers survive options applied after them, which still adjust the transport
+	httpClient := &http.Client{Transport: NewTransport()}
+	client, err := NewClient(wrap("inner"), wrap("outer"), SetHTTPClient(httpClient), SetHTTP2(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(wrapped) != "[inner outer]" {
+		t.Errorf("expected the wrappers to be applied in order; got: %v", wrapped)
+	}
+	outer, ok := client.httpClient.Transport.(struct{ http.RoundTripper })
+	if !ok {
+		t.Fatalf("expected the transport to be wrapped; got: %T", client.httpClient.Transport)
+	}
+	inner := outer.RoundTripper.(struct{ http.RoundTripper })
+	if transport := inner.RoundTripper.(*http.Transport); transport.ForceAttemptHTTP2 {
+		t.Error("expected HTTP/2 to be disabled")
+	}
+	if transport := httpClient.Transport.(*http.Transport); !transport.ForceAttemptHTTP2 {
+		t.Error("expected the client passed to SetHTTPClient to be left unwrapped and unchanged")
+	}
+}
+
+func TestSetHTTPClientAfterTransportOptions(t *testing.T) {
+	httpClient := &http.Client{Transport: NewTransport()}
+
+	// The transport options and SetTransport would be discarded, so SetHTTPClient has to come first
+	for name, option := range map[string]ClientOptionFunc{
+		"SetTransport": SetTransport(http.DefaultTransport),
+		"SetHTTP2":     SetHTTP2(false),
+	} {
+		_, err := NewClient(option, SetHTTPClient(httpClient))
+		if err == nil || !strings.Contains(err.Error(), "apply SetHTTPClient before") {
+			t.Errorf("expected an error applying SetHTTPClient after %s; got: %v", name, err)
+		}
+	}
+
+	client, err := NewClient(SetTimeout(time.Second), SetHTTPClient(httpClient), SetHTTP2(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.timeout != time.Second || client.httpClient.Transport.(*http.Transport).ForceAttemptHTTP2 {
+		t.Error("expected the timeout and the transport options to apply to the given client")
+	}
+}
+
+func TestSetHTTPClientLeftUnchanged(t *testing.T) {
+	transport := NewTransport()
+	for name, httpClient := range map[string]*http.Client{
+		"nil transport":    {},
+		"custom transport": {Transport: transport},
+	} {
+		before := *httpClient
+		client, err := NewClient(SetHTTPClient(httpClient), SetHTTP2(false), SetProxy(nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if client.httpClient == httpClient || httpClient.Transport != before.Transport {
+			t.Errorf("%s: expected the client passed to SetHTTPClient to be copied", name)
+		}
+		if client.httpClient.Transport == transport || client.httpClient.Transport.(*http.Transport).ForceAttemptHTTP2 {
+			t.Errorf("%s: expected the transport options to apply to a clone of the transport", name)
+		}
+	}
+	if !transport.ForceAttemptHTTP2 || transport.Proxy == nil {
+		t.Error("expected the transport passed to SetHTTPClient to be left unchanged")
+	}
+}
+
+// writeClientCertificate writes a self-signed client certificate and its key to dir,
+// and returns their paths and a pool to verify it with.
+func writeClientCertificate(t *testing.T, dir string) (string, string, *x509.CertPool) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "form3-client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, _ := x509.ParseCertificate(der)
+	pool := x509.NewCertPool()
+	pool.AddCert(certificate)
+
+	keyDER, _ := x509.MarshalPKCS8PrivateKey(key)
+	certFile, keyFile := filepath.Join(dir, "client.pem"), filepath.Join(dir, "client-key.pem")
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0600)
+	return certFile, keyFile, pool
+}
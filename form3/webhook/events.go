@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	form3 "github.com/form3tech-oss/interview-accountapi/form3"
+)
+
+// Resource types that Form3 sends notifications for.
+const (
+	ResourceAccounts           string = "accounts"
+	ResourcePaymentSubmissions string = "payment_submissions"
+	ResourceReturns            string = "returns"
+)
+
+// Event types that Form3 sends notifications for.
+const (
+	EventCreated string = "created"
+	EventUpdated string = "updated"
+	EventDeleted string = "deleted"
+)
+
+// Event is the envelope of every notification delivered by Form3.
+// Data holds the resource the event relates to and is decoded into a typed event before dispatch.
+type Event struct {
+	ID             string          `json:"id"`
+	OrganisationID string          `json:"organisation_id"`
+	EventType      string          `json:"event_type"`
+	ResourceType   string          `json:"resource_type"`
+	Version        int             `json:"version"`
+	Data           json.RawMessage `json:"data"`
+}
+
+// AccountEvent is an event about an account.
+type AccountEvent struct {
+	Event
+	Account form3.Account
+}
+
+// PaymentSubmission represents the submission of a payment to a payment scheme.
+type PaymentSubmission struct {
+	Attributes     PaymentSubmissionAttributes `json:"attributes"`
+	ID             string                      `json:"id"`
+	OrganisationID string                      `json:"organisation_id"`
+	Type           string                      `json:"type"`
+	Version        int                         `json:"version"`
+}
+
+// PaymentSubmissionAttributes represents attributes of a PaymentSubmission
+type PaymentSubmissionAttributes struct {
+	Status             string `json:"status"`
+	StatusReason       string `json:"status_reason,omitempty"`
+	SchemeStatusCode   string `json:"scheme_status_code,omitempty"`
+	SubmissionDatetime string `json:"submission_datetime,omitempty"`
+}
+
+// PaymentSubmissionEvent is an event about a payment submission.
+type PaymentSubmissionEvent struct {
+	Event
+	PaymentSubmission PaymentSubmission
+}
+
+// Return represents the return of a previously received payment.
+type Return struct {
+	Attributes     ReturnAttributes `json:"attributes"`
+	ID             string           `json:"id"`
+	OrganisationID string           `json:"organisation_id"`
+	Type           string           `json:"type"`
+	Version        int              `json:"version"`
+}
+
+// ReturnAttributes represents attributes of a Return
+type ReturnAttributes struct {
+	Amount     form3.Amount   `json:"amount"`
+	Currency   form3.Currency `json:"currency,omitempty"`
+	ReturnCode string         `json:"return_code"`
+	SchemeID   string         `json:"scheme_transaction_id,omitempty"`
+}
+
+// ReturnEvent is an event about a payment return.
+type ReturnEvent struct {
+	Event
+	Return Return
+}
+
+// errMalformedData is wrapped by errors decoding the event data into its typed event.
+var errMalformedData = errors.New("webhook: malformed event data")
+
+// decode unmarshals the event data into v.
+func (e *Event) decode(v interface{}) error {
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return fmt.Errorf("%w: %s", errMalformedData, err)
+	}
+	return nil
+}
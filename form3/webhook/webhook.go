@@ -0,0 +1,364 @@
+// Package webhook receives notifications delivered by Form3 subscriptions over HTTP.
+//
+// A Handler verifies each delivery, decodes it into a typed event and dispatches it to the registered callbacks:
+//
+//	h, err := webhook.NewHandler(secret)
+//	h.OnAccountCreated(func(ctx context.Context, e *webhook.AccountEvent) error {
+//		return store.Save(ctx, e.Account)
+//	})
+//	http.Handle("/form3/events", h)
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// SignatureHeader carries the hex encoded HMAC-SHA256 of the timestamp and body.
+	SignatureHeader string = "X-Form3-Signature"
+	// TimestampHeader carries the unix time (in seconds) at which the delivery was signed.
+	TimestampHeader string = "X-Form3-Timestamp"
+
+	defaultTolerance    time.Duration = 5 * time.Minute
+	defaultCacheSize    int           = 10000
+	defaultMaxBodyBytes int64         = 1 << 20
+)
+
+var (
+	// ErrInvalidSignature is returned when a delivery's signature does not match its body.
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+	// ErrStaleTimestamp is returned when a delivery was signed outside of the tolerance window.
+	ErrStaleTimestamp = errors.New("webhook: timestamp outside tolerance")
+)
+
+// Callback handles a decoded event. Returning an error causes Form3 to redeliver the event.
+type Callback func(ctx context.Context, event *Event) error
+
+// Handler is an http.Handler for Form3 notifications. Create one by calling NewHandler.
+type Handler struct {
+	secret    []byte
+	tolerance time.Duration
+	now       func() time.Time
+	errorLog  *log.Logger
+
+	mu        sync.RWMutex
+	callbacks map[string][]Callback
+	seen      *idempotencyCache
+}
+
+// HandlerOptionFunc is a function that configures a Handler.
+type HandlerOptionFunc func(*Handler) error
+
+// NewHandler creates a new Handler that verifies deliveries signed with secret.
+func NewHandler(secret string, options ...HandlerOptionFunc) (*Handler, error) {
+	if secret == "" {
+		return nil, errors.New("webhook: secret must not be empty")
+	}
+
+	h := &Handler{
+		secret:    []byte(secret),
+		tolerance: defaultTolerance,
+		now:       time.Now,
+		errorLog:  log.New(os.Stderr, "[form3_webhook_error]", log.LstdFlags),
+		callbacks: map[string][]Callback{},
+		seen:      newIdempotencyCache(defaultCacheSize),
+	}
+
+	// Apply passed options (if any), overriding defaults
+	for _, option := range options {
+		if err := option(h); err != nil {
+			return nil, err
+		}
+	}
+
+	return h, nil
+}
+
+// SetTolerance sets how far a delivery's timestamp may drift from the current time (5 minutes by default)
+func SetTolerance(tolerance time.Duration) HandlerOptionFunc {
+	return func(h *Handler) error {
+		if tolerance <= 0 {
+			return errors.New("webhook: tolerance must be positive")
+		}
+		h.tolerance = tolerance
+		return nil
+	}
+}
+
+// SetCacheSize sets how many delivered event IDs are remembered to drop duplicates (10000 by default)
+func SetCacheSize(size int) HandlerOptionFunc {
+	return func(h *Handler) error {
+		if size <= 0 {
+			return errors.New("webhook: cache size must be positive")
+		}
+		h.seen = newIdempotencyCache(size)
+		return nil
+	}
+}
+
+// SetErrorLog sets the logger for failed deliveries (stderr by default, nil to disable)
+func SetErrorLog(logger *log.Logger) HandlerOptionFunc {
+	return func(h *Handler) error {
+		h.errorLog = logger
+		return nil
+	}
+}
+
+// On registers a callback for every event with the given resource type and event type.
+func (h *Handler) On(resourceType, eventType string, callback Callback) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := callbackKey(resourceType, eventType)
+	h.callbacks[key] = append(h.callbacks[key], callback)
+}
+
+// OnAccountCreated registers a callback for account created events.
+func (h *Handler) OnAccountCreated(callback func(ctx context.Context, event *AccountEvent) error) {
+	h.On(ResourceAccounts, EventCreated, accountCallback(callback))
+}
+
+// OnAccountUpdated registers a callback for account updated events.
+func (h *Handler) OnAccountUpdated(callback func(ctx context.Context, event *AccountEvent) error) {
+	h.On(ResourceAccounts, EventUpdated, accountCallback(callback))
+}
+
+// OnAccountDeleted registers a callback for account deleted events.
+func (h *Handler) OnAccountDeleted(callback func(ctx context.Context, event *AccountEvent) error) {
+	h.On(ResourceAccounts, EventDeleted, accountCallback(callback))
+}
+
+// OnPaymentSubmissionCreated registers a callback for payment submission created events.
+func (h *Handler) OnPaymentSubmissionCreated(callback func(ctx context.Context, event *PaymentSubmissionEvent) error) {
+	h.On(ResourcePaymentSubmissions, EventCreated, paymentSubmissionCallback(callback))
+}
+
+// OnPaymentSubmissionUpdated registers a callback for payment submission updated events.
+func (h *Handler) OnPaymentSubmissionUpdated(callback func(ctx context.Context, event *PaymentSubmissionEvent) error) {
+	h.On(ResourcePaymentSubmissions, EventUpdated, paymentSubmissionCallback(callback))
+}
+
+// OnReturnCreated registers a callback for return created events.
+func (h *Handler) OnReturnCreated(callback func(ctx context.Context, event *ReturnEvent) error) {
+	h.On(ResourceReturns, EventCreated, returnCallback(callback))
+}
+
+// ServeHTTP verifies, decodes and dispatches a single delivery.
+//
+// Potential status codes:
+// - 200	OK	Delivery was handled, ignored (no callbacks) or is a duplicate of one handled or being handled
+// - 400	Bad Request	Body is not a valid event, or its data does not decode into the typed event
+// - 401	Unauthorized	Signature or timestamp failed verification
+// - 405	Method Not Allowed	Deliveries must be POSTed
+// - 500	Internal Server Error	A callback failed and Form3 should redeliver
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, defaultMaxBodyBytes))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Verify(r.Header, body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		http.Error(w, fmt.Sprintf("webhook: malformed event: %s", err), http.StatusBadRequest)
+		return
+	}
+	if event.ID == "" {
+		http.Error(w, "webhook: event id is missing", http.StatusBadRequest)
+		return
+	}
+
+	// Reserve the ID before dispatching, so concurrent deliveries of the same event are dispatched once.
+	if !h.seen.reserve(event.ID) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err := h.dispatch(r.Context(), &event); err != nil {
+		// Release the ID so that failures are processed again on redelivery.
+		h.seen.release(event.ID)
+		h.errorf("%s %s %s -> %s", event.ResourceType, event.EventType, event.ID, err.Error())
+
+		// Redelivering a payload that does not decode would fail forever, so do not ask for it
+		if errors.Is(err, errMalformedData) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// Verify checks the signature and timestamp headers of a delivery against its body.
+func (h *Handler) Verify(header http.Header, body []byte) error {
+	timestamp := header.Get(TimestampHeader)
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrStaleTimestamp
+	}
+
+	signed := time.Unix(seconds, 0)
+	drift := h.now().Sub(signed)
+	if drift < -h.tolerance || drift > h.tolerance {
+		return ErrStaleTimestamp
+	}
+
+	signature, err := hex.DecodeString(header.Get(SignatureHeader))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal(signature, sign(h.secret, timestamp, body)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// Sign returns the signature and timestamp headers Form3 would send for body at time t.
+// It is useful for testing handlers end to end.
+func Sign(secret string, t time.Time, body []byte) http.Header {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+
+	header := http.Header{}
+	header.Set(TimestampHeader, timestamp)
+	header.Set(SignatureHeader, hex.EncodeToString(sign([]byte(secret), timestamp, body)))
+	return header
+}
+
+func sign(secret []byte, timestamp string, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+func (h *Handler) dispatch(ctx context.Context, event *Event) error {
+	h.mu.RLock()
+	callbacks := h.callbacks[callbackKey(event.ResourceType, event.EventType)]
+	h.mu.RUnlock()
+
+	for _, callback := range callbacks {
+		if err := callback(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// errorf logs to the error log.
+func (h *Handler) errorf(format string, args ...interface{}) {
+	if h.errorLog != nil {
+		h.errorLog.Printf(format, args...)
+	}
+}
+
+func callbackKey(resourceType, eventType string) string {
+	return resourceType + "/" + eventType
+}
+
+func accountCallback(callback func(ctx context.Context, event *AccountEvent) error) Callback {
+	return func(ctx context.Context, event *Event) error {
+		typed := &AccountEvent{Event: *event}
+		if err := event.decode(&typed.Account); err != nil {
+			return err
+		}
+		return callback(ctx, typed)
+	}
+}
+
+func paymentSubmissionCallback(callback func(ctx context.Context, event *PaymentSubmissionEvent) error) Callback {
+	return func(ctx context.Context, event *Event) error {
+		typed := &PaymentSubmissionEvent{Event: *event}
+		if err := event.decode(&typed.PaymentSubmission); err != nil {
+			return err
+		}
+		return callback(ctx, typed)
+	}
+}
+
+func returnCallback(callback func(ctx context.Context, event *ReturnEvent) error) Callback {
+	return func(ctx context.Context, event *Event) error {
+		typed := &ReturnEvent{Event: *event}
+		if err := event.decode(&typed.Return); err != nil {
+			return err
+		}
+		return callback(ctx, typed)
+	}
+}
+
+// idempotencyCache remembers a bounded number of event IDs, evicting the oldest first.
+type idempotencyCache struct {
+	mu    sync.Mutex
+	size  int
+	ids   map[string]struct{}
+	order []string
+}
+
+func newIdempotencyCache(size int) *idempotencyCache {
+	return &idempotencyCache{
+		size: size,
+		ids:  make(map[string]struct{}, size),
+	}
+}
+
+// reserve adds id and reports whether it was absent, in one step.
+func (c *idempotencyCache) reserve(id string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.ids[id]; ok {
+		return false
+	}
+	if len(c.order) >= c.size {
+		oldest := c.order[0]
+		c.order = c.order[1:]
+		delete(c.ids, oldest)
+	}
+	c.ids[id] = struct{}{}
+	c.order = append(c.order, id)
+	return true
+}
+
+// release removes a reserved id, e.g. after its delivery failed.
+func (c *idempotencyCache) release(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.ids[id]; !ok {
+		return
+	}
+	delete(c.ids, id)
+	for i, reserved := range c.order {
+		if reserved == id {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+}
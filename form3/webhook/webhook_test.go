@@ -0,0 +1,232 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testSecret = "s3cr3t"
+
+func testHandler(t *testing.T) *Handler {
+	h, err := NewHandler(testSecret, SetErrorLog(log.New(ioutil.Discard, "", 0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func deliver(h *Handler, body string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/form3/events", bytes.NewBufferString(body))
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func Test_AccountCreated_Dispatched_Success(t *testing.T) {
+	h := testHandler(t)
+
+	var got *AccountEvent
+	h.OnAccountCreated(func(ctx context.Context, e *AccountEvent) error {
+		got = e
+		return nil
+	})
+
+	rec := deliver(h, accountCreatedJSON, Sign(testSecret, time.Now(), []byte(accountCreatedJSON)))
+
+	if http.StatusOK != rec.Code {
+		t.Error("Expected:", http.StatusOK, "Got:", rec.Code)
+	}
+
+	if got == nil {
+		t.Fatal("Expected callback to be called")
+	}
+
+	if got.Account.ID != "158f775c-4ecd-4861-b33d-30df9a29de78" {
+		t.Error("Expected: 158f775c-4ecd-4861-b33d-30df9a29de78", "Got:", got.Account.ID)
+	}
+
+	if got.Account.Attributes.Country != "GB" {
+		t.Error("Expected: GB", "Got:", got.Account.Attributes.Country)
+	}
+}
+
+func Test_InvalidSignature_Failure(t *testing.T) {
+	h := testHandler(t)
+
+	rec := deliver(h, accountCreatedJSON, Sign("wrong", time.Now(), []byte(accountCreatedJSON)))
+
+	if http.StatusUnauthorized != rec.Code {
+		t.Error("Expected:", http.StatusUnauthorized, "Got:", rec.Code)
+	}
+}
+
+func Test_StaleTimestamp_Failure(t *testing.T) {
+	h := testHandler(t)
+
+	header := Sign(testSecret, time.Now().Add(-time.Hour), []byte(accountCreatedJSON))
+	if err := h.Verify(header, []byte(accountCreatedJSON)); err != ErrStaleTimestamp {
+		t.Error("Expected:", ErrStaleTimestamp, "Got:", err)
+	}
+}
+
+func Test_FailedCallback_Redelivered(t *testing.T) {
+	h := testHandler(t)
+
+	calls := 0
+	h.OnAccountCreated(func(ctx context.Context, e *AccountEvent) error {
+		calls++
+		if calls == 1 {
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+
+	header := Sign(testSecret, time.Now(), []byte(accountCreatedJSON))
+
+	rec := deliver(h, accountCreatedJSON, header)
+	if http.StatusInternalServerError != rec.Code {
+		t.Error("Expected:", http.StatusInternalServerError, "Got:", rec.Code)
+	}
+
+	rec = deliver(h, accountCreatedJSON, header)
+	if http.StatusOK != rec.Code {
+		t.Error("Expected:", http.StatusOK, "Got:", rec.Code)
+	}
+
+	if calls != 2 {
+		t.Error("Expected: 2 calls", "Got:", calls)
+	}
+}
+
+func Test_DuplicateDelivery_Dropped(t *testing.T) {
+	h := testHandler(t)
+
+	calls := 0
+	h.OnAccountCreated(func(ctx context.Context, e *AccountEvent) error {
+		calls++
+		return nil
+	})
+
+	header := Sign(testSecret, time.Now(), []byte(accountCreatedJSON))
+	deliver(h, accountCreatedJSON, header)
+	rec := deliver(h, accountCreatedJSON, header)
+
+	if http.StatusOK != rec.Code {
+		t.Error("Expected:", http.StatusOK, "Got:", rec.Code)
+	}
+
+	if calls != 1 {
+		t.Error("Expected: 1 call", "Got:", calls)
+	}
+}
+
+func Test_IdempotencyCache_EvictsOldest(t *testing.T) {
+	c := newIdempotencyCache(2)
+	c.reserve("a")
+	c.reserve("b")
+	c.reserve("c")
+
+	if c.reserve("b") || c.reserve("c") {
+		t.Error("Expected b and c to be retained")
+	}
+	if !c.reserve("a") {
+		t.Error("Expected a to be evicted")
+	}
+}
+
+func Test_ConcurrentDuplicateDelivery_DispatchedOnce(t *testing.T) {
+	h := testHandler(t)
+
+	var mu sync.Mutex
+	calls := 0
+	started, finish := make(chan struct{}), make(chan struct{})
+	h.OnAccountCreated(func(ctx context.Context, e *AccountEvent) error {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		close(started)
+		<-finish
+		return nil
+	})
+
+	header := Sign(testSecret, time.Now(), []byte(accountCreatedJSON))
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- deliver(h, accountCreatedJSON, header) }()
+
+	// The second delivery arrives while the first is still being handled
+	<-started
+	rec := deliver(h, accountCreatedJSON, header)
+	close(finish)
+	<-done
+
+	if http.StatusOK != rec.Code {
+		t.Error("Expected:", http.StatusOK, "Got:", rec.Code)
+	}
+	if calls != 1 {
+		t.Error("Expected: 1 call", "Got:", calls)
+	}
+}
+
+func Test_MalformedData_Failure(t *testing.T) {
+	h := testHandler(t)
+
+	called := false
+	h.OnAccountCreated(func(ctx context.Context, e *AccountEvent) error {
+		called = true
+		return nil
+	})
+
+	body := `{"id": "0b0a0b6e-8e8e-4b5e-a1a1-2b2b3c3c4d4d", "event_type": "created", "resource_type": "accounts", "data": {"attributes": "not an object"}}`
+	rec := deliver(h, body, Sign(testSecret, time.Now(), []byte(body)))
+
+	// 4xx, so that Form3 does not redeliver it forever
+	if http.StatusBadRequest != rec.Code {
+		t.Error("Expected:", http.StatusBadRequest, "Got:", rec.Code)
+	}
+	if called {
+		t.Error("Expected callback not to be called")
+	}
+}
+
+func Test_IdempotencyCache_Release(t *testing.T) {
+	c := newIdempotencyCache(2)
+	if !c.reserve("a") || c.reserve("a") {
+		t.Error("Expected a to be reserved once")
+	}
+
+	c.release("a")
+	if !c.reserve("a") {
+		t.Error("Expected a to be reserved again after its release")
+	}
+}
+
+var accountCreatedJSON = `{
+	"id": "0b0a0b6e-8e8e-4b5e-a1a1-2b2b3c3c4d4d",
+	"organisation_id": "158f775d-4ecd-4861-b33d-30df9a29de78",
+	"event_type": "created",
+	"resource_type": "accounts",
+	"version": 0,
+	"data": {
+		"type": "accounts",
+		"id": "158f775c-4ecd-4861-b33d-30df9a29de78",
+		"version": 0,
+		"organisation_id": "158f775d-4ecd-4861-b33d-30df9a29de78",
+		"attributes": {
+			"country": "GB",
+			"bank_id": "400300",
+			"bank_id_code": "GBDSC"
+		}
+	}
+}`
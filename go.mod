@@ -0,0 +1,5 @@
+module github.com/form3tech-oss/interview-accountapi
+
+go 1.22
+
+require gopkg.in/yaml.v3 v3.0.1